@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// runAnalyze runs the analyze command with args and returns what it logged
+// and wrote to stderr.
+func runAnalyze(t *testing.T, args ...string) (logged, stderr string) {
+	t.Helper()
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	errFile, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer errFile.Close()
+	defer func(f *os.File) { os.Stderr = f }(os.Stderr)
+	os.Stderr = errFile
+
+	cmd := analyzeCmd()
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(errFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logs.String(), string(data)
+}
+
+func TestAnalyzeQuiet(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/quiet\n\ngo 1.21\n")
+	writeFile(t, filepath.Join(dir, "controllers", "controller.go"), cachedController)
+
+	tests := []struct {
+		name  string
+		quiet bool
+	}{
+		{name: "default"},
+		{name: "quiet", quiet: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := []string{"--path", dir, "-o", filepath.Join(t.TempDir(), "results.jsonl")}
+			if tt.quiet {
+				args = append(args, "--quiet")
+			}
+			logged, stderr := runAnalyze(t, args...)
+			if progress := strings.Contains(logged, "Processing repository"); progress == tt.quiet {
+				t.Errorf("progress logged = %t, want %t:\n%s", progress, !tt.quiet, logged)
+			}
+			if summary := stderr != ""; summary == tt.quiet {
+				t.Errorf("summary printed = %t, want %t:\n%s", summary, !tt.quiet, stderr)
+			}
+		})
+	}
+}
+
+func TestAnalyzeSubdirOutsideRepo(t *testing.T) {
+	for _, subdir := range []string{"../other", "/abs/operator"} {
+		cmd := analyzeCmd()
+		cmd.SetArgs([]string{"--path", t.TempDir(), "--subdir", subdir})
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --subdir") {
+			t.Errorf("--subdir %q: error = %v, want it rejected", subdir, err)
+		}
+	}
+}
+
+// steppingClock returns a clock whose n-th reading (from 0) is n seconds
+// after the previous one, so every measured interval is distinct.
+func steppingClock() func() time.Time {
+	var mu sync.Mutex
+	t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	step := time.Duration(0)
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		t = t.Add(step)
+		step += time.Second
+		return t
+	}
+}
+
+func TestAnalyzeTimings(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/timed\n\ngo 1.21\n")
+	writeFile(t, filepath.Join(dir, "controllers", "controller.go"), cachedController)
+
+	defer func(f func() time.Time) { now = f }(now)
+	now = steppingClock()
+
+	timingFile := filepath.Join(t.TempDir(), "timings.json")
+	_, stderr := runAnalyze(t, "--path", dir, "-o", filepath.Join(t.TempDir(), "results.jsonl"), "--timing-output", timingFile)
+
+	// Readings: start 0s, clone 1s-3s, analyze 6s-10s, end 15s.
+	data, err := os.ReadFile(timingFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		WallClock time.Duration       `json:"wall_clock_ns"`
+		Repos     []models.RepoTiming `json:"repos"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	want := []models.RepoTiming{{Repo: dir, Clone: 2 * time.Second, Analyze: 4 * time.Second}}
+	if report.WallClock != 15*time.Second || !reflect.DeepEqual(report.Repos, want) {
+		t.Errorf("timings = %s %+v, want 15s %+v", report.WallClock, report.Repos, want)
+	}
+
+	for _, line := range []string{
+		"Total Wall-Clock: 15s",
+		"1. " + dir + " (clone: 2s, analyze: 4s)",
+	} {
+		if !strings.Contains(stderr, line) {
+			t.Errorf("summary lacks %q:\n%s", line, stderr)
+		}
+	}
+}
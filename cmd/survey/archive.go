@@ -0,0 +1,216 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// archiveExts are the supported archive extensions, longest first.
+var archiveExts = []string{".tar.gz", ".tgz", ".zip"}
+
+// archiveRepo describes a source archive as a repository, named after the
+// archive's basename.
+func archiveRepo(path string) (models.Repository, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return models.Repository{}, fmt.Errorf("invalid archive %s: %w", path, err)
+	}
+	if info, err := os.Stat(absPath); err != nil || info.IsDir() {
+		return models.Repository{}, fmt.Errorf("archive %s is not a file", path)
+	}
+
+	base := filepath.Base(absPath)
+	name := ""
+	for _, ext := range archiveExts {
+		if strings.HasSuffix(strings.ToLower(base), ext) {
+			name = base[:len(base)-len(ext)]
+			break
+		}
+	}
+	if name == "" {
+		return models.Repository{}, fmt.Errorf("archive %s: unsupported format (expected .tar.gz, .tgz or .zip)", path)
+	}
+
+	return models.Repository{
+		URL:     absPath,
+		Owner:   "archive",
+		Name:    name,
+		Source:  "archive",
+		Archive: absPath,
+	}, nil
+}
+
+// extractArchive extracts a repository archive into the work directory and
+// returns the extracted tree, in a directory unique to the archive's path.
+// An archive holding a single top-level directory, as produced by most
+// forges, is unwrapped.
+func extractArchive(repo models.Repository, workDir string, verbose bool) (string, error) {
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid work directory %s: %w", workDir, err)
+	}
+	// Archives of the same name from different directories must not share
+	// (and concurrently clean) one extraction directory.
+	sum := sha256.Sum256([]byte(repo.Archive))
+	dest := filepath.Join(absWorkDir, repo.Owner, repo.Name+"-"+hex.EncodeToString(sum[:6]))
+	if err := os.RemoveAll(dest); err != nil {
+		return "", fmt.Errorf("failed to clean %s: %w", dest, err)
+	}
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if verbose {
+		log.Printf("Extracting %s to %s", repo.Archive, dest)
+	}
+
+	if strings.HasSuffix(strings.ToLower(repo.Archive), ".zip") {
+		err = extractZip(repo.Archive, dest)
+	} else {
+		err = extractTarGz(repo.Archive, dest)
+	}
+	if err != nil {
+		os.RemoveAll(dest)
+		return "", fmt.Errorf("failed to extract %s: %w", repo.Archive, err)
+	}
+
+	return unwrapSingleDir(dest)
+}
+
+// extractTarGz extracts the regular files and directories of a .tar.gz.
+func extractTarGz(path, dest string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := archiveTarget(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip extracts the regular files and directories of a .zip.
+func extractZip(path, dest string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		target, err := archiveTarget(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(target, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveTarget resolves an archive entry name under dest, rejecting
+// entries that would escape it.
+func archiveTarget(dest, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if target != dest && !strings.HasPrefix(target, dest+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry %q escapes the extraction directory", name)
+	}
+	return target, nil
+}
+
+// writeArchiveFile writes an extracted file, creating its parent directories.
+func writeArchiveFile(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// unwrapSingleDir moves the contents of dest's only entry up into dest if
+// that entry is a directory.
+func unwrapSingleDir(dest string) (string, error) {
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return dest, nil
+	}
+
+	tmp := dest + ".unwrap"
+	if err := os.Rename(filepath.Join(dest, entries[0].Name()), tmp); err != nil {
+		return "", err
+	}
+	if err := os.Remove(dest); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// writeTarGz writes files into a .tar.gz at path.
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeZip writes files into a .zip at path.
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	src := t.TempDir()
+	tests := []struct {
+		name    string
+		archive string
+		write   func(*testing.T, string, map[string]string)
+		files   map[string]string
+		want    string // path of main.go in the extracted tree
+	}{
+		{
+			name:    "wrapped tar.gz",
+			archive: filepath.Join(src, "v1", "operator.tar.gz"),
+			write:   writeTarGz,
+			files:   map[string]string{"operator-1.0/main.go": "v1"},
+			want:    "main.go",
+		},
+		{
+			name:    "same name in another directory",
+			archive: filepath.Join(src, "v2", "operator.tar.gz"),
+			write:   writeTarGz,
+			files:   map[string]string{"operator-2.0/main.go": "v2"},
+			want:    "main.go",
+		},
+		{
+			name:    "flat zip",
+			archive: filepath.Join(src, "operator.zip"),
+			write:   writeZip,
+			files:   map[string]string{"main.go": "zip", "go.mod": "module x\n"},
+			want:    "main.go",
+		},
+	}
+
+	workDir := t.TempDir()
+	dests := make([]string, len(tests))
+	var wg sync.WaitGroup
+	for i, tt := range tests {
+		if err := os.MkdirAll(filepath.Dir(tt.archive), 0755); err != nil {
+			t.Fatal(err)
+		}
+		tt.write(t, tt.archive, tt.files)
+		repo, err := archiveRepo(tt.archive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Extract concurrently, as the analyze workers do.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dest, err := extractArchive(repo, workDir, false)
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			dests[i] = dest
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]string)
+	for i, tt := range tests {
+		if prev, ok := seen[dests[i]]; ok {
+			t.Errorf("%s and %s extracted to the same directory %s", prev, tt.name, dests[i])
+		}
+		seen[dests[i]] = tt.name
+
+		got, err := os.ReadFile(filepath.Join(dests[i], tt.want))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		for name, content := range tt.files {
+			if filepath.Base(name) == tt.want && string(got) != content {
+				t.Errorf("%s: %s = %q, want %q", tt.name, tt.want, got, content)
+			}
+		}
+	}
+}
+
+func TestArchiveTargetEscape(t *testing.T) {
+	dest := t.TempDir()
+	for _, name := range []string{"../evil.go", "a/../../evil.go"} {
+		if _, err := archiveTarget(dest, name); err == nil {
+			t.Errorf("archiveTarget(%q) succeeded, want error", name)
+		}
+	}
+	if _, err := archiveTarget(dest, "a/../ok.go"); err != nil {
+		t.Errorf("archiveTarget(a/../ok.go): %v", err)
+	}
+}
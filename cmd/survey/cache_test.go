@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/analyzer"
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+	"github.com/rg0now/k8s-controller-survey/pkg/output"
+)
+
+const cachedController = `package controllers
+
+type Context interface{}
+type Request struct{ Name string }
+type Result struct{}
+
+type Client interface {
+	List(ctx Context, list interface{}, opts ...interface{}) error
+}
+
+type PodList struct{}
+
+type PodReconciler struct{ Client Client }
+
+func (r *PodReconciler) Reconcile(ctx Context, req Request) (Result, error) {
+	var pods PodList
+	return Result{}, r.Client.List(ctx, &pods)
+}
+`
+
+func TestAnalyzeCached(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		"go.mod":                    "module example.com/cached\n\ngo 1.21\n",
+		"controllers/controller.go": cachedController,
+	})
+	repo := models.Repository{URL: "https://example.com/cached", LocalPath: dir}
+	a := analyzer.NewAnalyzer(t.TempDir(), false)
+	cache := &output.ResultCache{Dir: t.TempDir()}
+
+	first, _, err := analyzeCached(a, repo, cache, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("first run found %d reconcilers, want 1", len(first))
+	}
+
+	// An uncommitted change is invisible to the cache key: the second run
+	// must come from the cache rather than re-analyze the work tree.
+	if err := os.Remove(filepath.Join(dir, "controllers", "controller.go")); err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := analyzeCached(a, repo, cache, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(second, first) {
+		t.Errorf("second run = %+v, want the cached %+v", second, first)
+	}
+
+	// Local checkouts are never cached.
+	local, _, _ := analyzeCached(a, repo, cache, false, false)
+	if len(local) != 0 {
+		t.Errorf("uncached run found %d reconcilers, want 0", len(local))
+	}
+}
+
+func TestCacheVersion(t *testing.T) {
+	defer func(v string) { version = v }(version)
+
+	version = "v1.2.3"
+	if got := cacheVersion(); got != "v1.2.3" {
+		t.Errorf("cacheVersion() = %q, want the release version", got)
+	}
+	for _, v := range []string{"dev", "v1.2.4-0.20240101000000-abcdef123456+dirty"} {
+		version = v
+		if got := cacheVersion(); got != binaryHash() || got == "" {
+			t.Errorf("cacheVersion() for %q = %q, want the binary hash", v, got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloneArgs(t *testing.T) {
+	const url, path = "https://github.com/acme/widgets", "/work/acme/widgets"
+	tests := []struct {
+		name string
+		opts cloneOptions
+		want []string
+	}{
+		{
+			name: "full history of the default branch",
+			opts: cloneOptions{},
+			want: []string{"clone", url, path},
+		},
+		{
+			name: "shallow",
+			opts: cloneOptions{Depth: 1},
+			want: []string{"clone", "--depth=1", url, path},
+		},
+		{
+			name: "tag with history",
+			opts: cloneOptions{Depth: 50, Ref: "v1.14.0"},
+			want: []string{"clone", "--depth=50", "--branch", "v1.14.0", url, path},
+		},
+		{
+			name: "shallow submodules",
+			opts: cloneOptions{Depth: 1, RecurseSubmodules: true},
+			want: []string{"clone", "--depth=1", "--recurse-submodules", "--shallow-submodules", url, path},
+		},
+		{
+			name: "submodules with full history",
+			opts: cloneOptions{RecurseSubmodules: true},
+			want: []string{"clone", "--recurse-submodules", url, path},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cloneArgs(url, path, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cloneArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfig reads a config file (YAML or JSON) that supplies flag defaults
+// per command, keyed by command name and then by flag name:
+//
+//	analyze:
+//	  work-dir: /data/repos
+//	  num-workers: 8
+//	  skip-package-regex: /vendor/
+//	calibrate:
+//	  profile: profile.yaml
+func loadConfig(path string) (map[string]map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var config map[string]map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// applyConfig sets the flags of cmd from its section of the config file.
+// Flags given explicitly on the command line take precedence.
+func applyConfig(cmd *cobra.Command, path string) error {
+	config, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range config[cmd.Name()] {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("config %s: unknown flag %q for %s", path, name, cmd.Name())
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := setFlag(flag, value); err != nil {
+			return fmt.Errorf("config %s: invalid value for %s: %w", path, name, err)
+		}
+	}
+	return nil
+}
+
+// setFlag sets a flag from a decoded config value. Lists set each element,
+// which appends for slice and array flags.
+func setFlag(flag *pflag.Flag, value interface{}) error {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	for _, v := range values {
+		if err := flag.Value.Set(fmt.Sprint(v)); err != nil {
+			return err
+		}
+	}
+	flag.Changed = true
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// configTestCmd is a command named analyze with a flag of each kind.
+func configTestCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "analyze"}
+	cmd.Flags().String("work-dir", "./repos", "")
+	cmd.Flags().Int("num-workers", 3, "")
+	cmd.Flags().Bool("keep-clones", false, "")
+	cmd.Flags().StringSlice("owner", nil, "")
+	return cmd
+}
+
+func TestApplyConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		args    []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:   "defaults from the command's section",
+			config: "analyze:\n  work-dir: /data\n  num-workers: 8\n  keep-clones: true\n  owner: [a, b]\ncalibrate:\n  profile: p.yaml\n",
+			want:   map[string]string{"work-dir": "/data", "num-workers": "8", "keep-clones": "true", "owner": "[a,b]"},
+		},
+		{
+			name:   "explicit flags win",
+			config: "analyze:\n  work-dir: /data\n  num-workers: 8\n",
+			args:   []string{"--num-workers=2"},
+			want:   map[string]string{"work-dir": "/data", "num-workers": "2"},
+		},
+		{
+			name:   "json",
+			config: `{"analyze": {"work-dir": "/json"}}`,
+			want:   map[string]string{"work-dir": "/json"},
+		},
+		{name: "no section", config: "calibrate:\n  profile: p.yaml\n", want: map[string]string{"work-dir": "./repos"}},
+		{name: "unknown flag", config: "analyze:\n  nope: 1\n", wantErr: `unknown flag "nope"`},
+		{name: "invalid value", config: "analyze:\n  num-workers: many\n", wantErr: "invalid value for num-workers"},
+		{name: "malformed", config: "analyze: [\n", wantErr: "failed to parse config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeFile(t, path, tt.config)
+			cmd := configTestCmd()
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := applyConfig(cmd, path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("applyConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for name := range tt.want {
+				got[name] = cmd.Flags().Lookup(name).Value.String()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := applyConfig(configTestCmd(), filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("applyConfig with a missing file succeeded, want error")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// gitRepo creates a git work tree with an initial commit of files.
+func gitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	for name, content := range files {
+		writeFile(t, filepath.Join(dir, name), content)
+	}
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffFiles(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		"main.go":                           "package main\n",
+		"operator/controllers/foo.go":       "package controllers\n",
+		"operator/controllers/.gitignore":   "*.tmp\n",
+		"operator/controllers/unchanged.go": "package controllers\n",
+	})
+	writeFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc main() {}\n")
+	writeFile(t, filepath.Join(dir, "operator/controllers/foo.go"), "package controllers\n\n// changed\n")
+	writeFile(t, filepath.Join(dir, "operator/controllers/new.go"), "package controllers\n")
+	writeFile(t, filepath.Join(dir, "operator/controllers/scratch.tmp"), "ignored\n")
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "work tree root",
+			path: dir,
+			want: []string{"main.go", "operator/controllers/foo.go", "operator/controllers/new.go"},
+		},
+		{
+			name: "subdirectory",
+			path: filepath.Join(dir, "operator"),
+			want: []string{"controllers/foo.go", "controllers/new.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffFiles(tt.path, "HEAD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := diffFiles(dir, "no-such-ref"); err == nil {
+		t.Error("diffFiles with an unknown ref succeeded, want error")
+	}
+}
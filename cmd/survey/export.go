@@ -0,0 +1,82 @@
+//go:build sqlite
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/output"
+	"github.com/spf13/cobra"
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	extraCommands = append(extraCommands, exportCmd)
+}
+
+// exportCmd writes JSONL results into a SQLite database.
+func exportCmd() *cobra.Command {
+	var (
+		inputs     []string
+		outputFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export JSONL results to a SQLite database",
+		Long: `Load JSONL result files, de-duplicated by reconciler ID as in merge, into a
+SQLite database with a reconcilers table and a signals table whose
+reconciler_id references reconcilers(id). The database is replaced if it
+exists.
+
+Examples:
+  k8s-controller-survey export --input=results.jsonl --output=survey.db
+  sqlite3 survey.db "SELECT owner, COUNT(*) FROM reconcilers WHERE classification = 'sotw' GROUP BY owner"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sets []output.ResultSet
+			for _, input := range inputs {
+				paths, err := resultFiles(input)
+				if err != nil {
+					return err
+				}
+				for _, path := range paths {
+					reconcilers, err := loadReconcilersFromFile(path)
+					if err != nil {
+						return fmt.Errorf("failed to load results from %s: %w", path, err)
+					}
+					sets = append(sets, output.ResultSet{Source: path, Reconcilers: reconcilers})
+				}
+			}
+
+			merged, conflicts, err := output.Merge(sets)
+			if err != nil {
+				return err
+			}
+			output.PrintConflicts(os.Stderr, conflicts)
+
+			if err := os.Remove(outputFile); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to replace %s: %w", outputFile, err)
+			}
+			db, err := sql.Open("sqlite", outputFile)
+			if err != nil {
+				return fmt.Errorf("failed to open database: %w", err)
+			}
+			defer db.Close()
+
+			if err := output.ExportSQLite(db, merged); err != nil {
+				return err
+			}
+			log.Printf("Exported %d reconcilers to %s", len(merged), outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&inputs, "input", "i", nil, "Input JSONL file or directory of them (repeatable)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "survey.db", "SQLite database to write")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
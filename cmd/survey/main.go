@@ -8,26 +8,54 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/rg0now/k8s-controller-survey/pkg/analyzer"
 	"github.com/rg0now/k8s-controller-survey/pkg/models"
 	"github.com/rg0now/k8s-controller-survey/pkg/output"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
+// now returns the current time; overridable so timing can be faked.
+var now = time.Now
+
+// extraCommands are subcommands registered by build-tagged files.
+var extraCommands []func() *cobra.Command
+
 func main() {
+	var configFile string
+
 	rootCmd := &cobra.Command{
 		Use:   "k8s-controller-survey",
 		Short: "Analyze Kubernetes controllers for SoTW vs edge-triggered patterns",
 		Long: `A static analysis tool to classify Kubernetes controllers as
 State-of-the-World (SoTW) vs Edge-Triggered based on their
 reconciliation patterns.`,
+		Version: toolVersion(),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if configFile == "" {
+				return nil
+			}
+			return applyConfig(cmd, configFile)
+		},
 	}
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "YAML or JSON file with per-command flag defaults (explicit flags take precedence)")
 
 	rootCmd.AddCommand(analyzeCmd())
 	rootCmd.AddCommand(reportCmd())
+	rootCmd.AddCommand(calibrateCmd())
+	rootCmd.AddCommand(signalsCmd())
+	rootCmd.AddCommand(trendCmd())
+	rootCmd.AddCommand(mergeCmd())
+	rootCmd.AddCommand(goldenCmd())
+	rootCmd.AddCommand(versionCmd())
+	for _, extra := range extraCommands {
+		rootCmd.AddCommand(extra())
+	}
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -40,11 +68,42 @@ func analyzeCmd() *cobra.Command {
 	var (
 		reposFile  string
 		numWorkers int32
+		fnWorkers  int
 		repoURLs   []string
 		outputFile string
+		outputDir  string
 		workDir    string
 		keepClones bool
 		verbose    bool
+		timingFile string
+		covFile    string
+		errorsFile string
+		inclTests  bool
+		strictSig  bool
+		snippetLen int
+		formatSnip bool
+		inclRecv   string
+		exclRecv   string
+		skipPkg    string
+		subdir     string
+		cacheDir   string
+		noCache    bool
+		compress   bool
+		explode    bool
+		localPaths []string
+		archives   []string
+		changed    []string
+		cloneDepth int
+		cloneRef   string
+		submodules bool
+		neutral    []string
+		explain    bool
+		diffBase   string
+		dryRun     bool
+		listRecs   bool
+		minStars   int
+		linkBase   string
+		quiet      bool
 	)
 
 	cmd := &cobra.Command{
@@ -60,8 +119,54 @@ Examples:
   k8s-controller-survey analyze --repo=https://github.com/cert-manager/cert-manager
 
   # Analyze with verbose output
-  k8s-controller-survey analyze --repo=https://github.com/cert-manager/cert-manager --verbose`,
+  k8s-controller-survey analyze --repo=https://github.com/cert-manager/cert-manager --verbose
+
+  # Analyze a local checkout without cloning
+  k8s-controller-survey analyze --path=./my-operator
+
+  # Analyze a source archive
+  k8s-controller-survey analyze --archive=my-operator.tar.gz
+
+  # Analyze only the reconcilers touched since main, e.g. in a PR check
+  k8s-controller-survey analyze --path=. --diff-base=origin/main
+
+  # Count packages and Reconcile functions without analyzing them
+  k8s-controller-survey analyze --repos=repos.txt --dry-run
+
+  # List discovered reconcilers as JSONL, without analyzing them
+  k8s-controller-survey analyze --repos=repos.txt --list-reconcilers
+
+  # Analyze only manifest entries with at least 500 stars
+  k8s-controller-survey analyze --repos=repos.yaml --min-stars=500`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Compile receiver filters up front so typos fail fast.
+			var inclRecvRe, exclRecvRe *regexp.Regexp
+			if inclRecv != "" {
+				re, err := regexp.Compile(inclRecv)
+				if err != nil {
+					return fmt.Errorf("invalid --include-receiver pattern: %w", err)
+				}
+				inclRecvRe = re
+			}
+			if exclRecv != "" {
+				re, err := regexp.Compile(exclRecv)
+				if err != nil {
+					return fmt.Errorf("invalid --exclude-receiver pattern: %w", err)
+				}
+				exclRecvRe = re
+			}
+			var skipPkgRe *regexp.Regexp
+			if skipPkg != "" {
+				re, err := regexp.Compile(skipPkg)
+				if err != nil {
+					return fmt.Errorf("invalid --skip-package-regex pattern: %w", err)
+				}
+				skipPkgRe = re
+			}
+			if subdir != "" && !filepath.IsLocal(subdir) {
+				return fmt.Errorf("invalid --subdir %q: must be a relative path inside the repository", subdir)
+			}
+
 			// Collect repos to analyze.
 			var repos []models.Repository
 
@@ -71,11 +176,15 @@ Examples:
 				if err != nil {
 					return fmt.Errorf("failed to load repos from file: %w", err)
 				}
+				if minStars > 0 {
+					fileRepos = filterByStars(fileRepos, minStars)
+				}
 				repos = append(repos, fileRepos...)
 			}
 
 			// Add individual repos from flags.
 			for _, url := range repoURLs {
+				url = analyzer.NormalizeRepoURL(url)
 				owner, name := analyzer.ParseRepoURL(url)
 				repos = append(repos, models.Repository{
 					URL:    url,
@@ -85,10 +194,58 @@ Examples:
 				})
 			}
 
+			// Add local checkouts from flags.
+			for _, path := range localPaths {
+				repo, err := localRepo(path)
+				if err != nil {
+					return err
+				}
+				repos = append(repos, repo)
+			}
+
+			// Add source archives from flags.
+			for _, path := range archives {
+				repo, err := archiveRepo(path)
+				if err != nil {
+					return err
+				}
+				repos = append(repos, repo)
+			}
+
 			if len(repos) == 0 {
 				return fmt.Errorf("no repositories specified")
 			}
 
+			if len(changed) > 0 || diffBase != "" {
+				if len(repos) != 1 || len(localPaths) != 1 {
+					return fmt.Errorf("--changed-files and --diff-base require a single --path repository")
+				}
+				if diffBase != "" {
+					files, err := diffFiles(repos[0].LocalPath, diffBase)
+					if err != nil {
+						return err
+					}
+					changed = append(changed, files...)
+				}
+				if len(changed) == 0 {
+					log.Printf("No changed files; nothing to analyze")
+					return nil
+				}
+			}
+
+			if outputFile != "" && outputDir != "" {
+				return fmt.Errorf("--output and --output-dir are mutually exclusive")
+			}
+
+			if snippetLen < 0 {
+				return fmt.Errorf("--snippet-length must not be negative")
+			}
+
+			if cloneDepth < 0 {
+				return fmt.Errorf("--clone-depth must not be negative (use 0 for full history)")
+			}
+			clone := cloneOptions{Depth: cloneDepth, Ref: cloneRef, RecurseSubmodules: submodules}
+
 			// Create work directory.
 			if err := os.MkdirAll(workDir, 0755); err != nil {
 				return fmt.Errorf("failed to create work directory: %w", err)
@@ -96,31 +253,79 @@ Examples:
 
 			// Create analyzer.
 			a := analyzer.NewAnalyzer(workDir, verbose)
+			a.IncludeTests = inclTests
+			a.StrictSignature = strictSig
+			a.FuncWorkers = fnWorkers
+			a.SnippetLength = snippetLen
+			a.NeutralKinds = neutral
+			a.Explain = explain
+			a.FormatSnippets = formatSnip
+			a.IncludeReceiver = inclRecvRe
+			a.ExcludeReceiver = exclRecvRe
+			a.SkipPackage = skipPkgRe
+			a.ChangedFiles = changed
+			a.Subdir = subdir
+
+			var cache *output.ResultCache
+			if cacheDir != "" && !noCache {
+				cache = &output.ResultCache{Dir: cacheDir}
+			}
+
+			if dryRun {
+				return runDryRun(a, repos, workDir, clone, verbose, keepClones)
+			}
+			if listRecs {
+				return runListReconcilers(a, repos, workDir, clone, verbose, keepClones)
+			}
 
 			// Create output writer.
-			w, err := output.NewWriter(outputFile)
+			var w *output.Writer
+			var err error
+			if outputDir != "" {
+				w, err = output.NewDirWriter(outputDir, compress)
+			} else {
+				w, err = output.NewWriter(outputFile, compress)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to create output writer: %w", err)
 			}
-			defer w.Close()
+			w.ExplodeSignals = explode
+			defer func() {
+				if err := w.Close(); err != nil {
+					log.Printf("Error closing output: %v", err)
+				}
+			}()
 
 			// Analyze each repo.
-			var allReconcilers []models.Reconciler
+			acc := output.NewSummaryAccumulator(10)
+			var timings []models.RepoTiming
+			var coverage []models.RepoInventory
+			var repoErrors []models.RepoError
+			start := now()
 			wg := &sync.WaitGroup{}
-			wg.Add(len(repos))
 			mutex := &sync.Mutex{}
+			recordError := func(repo models.Repository, phase string, err error) {
+				mutex.Lock()
+				repoErrors = append(repoErrors, models.RepoError{Repo: repo.URL, Phase: phase, Error: err.Error()})
+				mutex.Unlock()
+			}
 			signalChan := make(chan bool, numWorkers)
 			for _, repo := range repos {
 				// Clone repository.
-				localPath, err := cloneRepo(repo.URL, workDir, verbose)
+				cloneStart := now()
+				repo, cloned, err := prepareRepo(repo, workDir, clone, verbose)
 				if err != nil {
 					log.Printf("Error cloning %s: %v", repo.URL, err)
+					recordError(repo, models.PhaseClone, err)
 					continue
 				}
-				repo.LocalPath = localPath
+				cloneDuration := now().Sub(cloneStart)
 				// "Put a foot in the door", aka write to the channel, will block if channel is full
 				signalChan <- false
-				log.Printf("Processing repository: %s", repo.URL)
+				wg.Add(1)
+				if !quiet {
+					log.Printf("Processing repository: %s", repo.URL)
+				}
 				// Analyze.
 				go func() {
 					defer func() {
@@ -128,55 +333,209 @@ Examples:
 						// Read out a value of the channel, freeing up a space in the buffer and allowing another repo to be analyzed
 						<-signalChan
 					}()
-					reconcilers, err := a.AnalyzeRepo(repo)
+					analyzeStart := now()
+					reconcilers, inv, err := analyzeCached(a, repo, cache, cloned, verbose)
+					timing := models.RepoTiming{
+						Repo:    repo.URL,
+						Clone:   cloneDuration,
+						Analyze: now().Sub(analyzeStart),
+					}
+					mutex.Lock()
+					timings = append(timings, timing)
+					coverage = append(coverage, inv)
+					mutex.Unlock()
 					if err != nil {
 						log.Printf("Error analyzing %s: %v", repo.URL, err)
+						recordError(repo, models.PhaseAnalyze, err)
 						return
 					}
 
-					log.Printf("Found %d reconcilers in %s", len(reconcilers), repo.URL)
+					if !quiet {
+						log.Printf("Found %d reconcilers in %s", len(reconcilers), repo.URL)
+					}
+
+					if linkBase != "" {
+						output.AddPermalinks(reconcilers, output.LinkBase(linkBase, repo), headCommit(repo.LocalPath))
+					}
+
+					for i := range reconcilers {
+						reconcilers[i].ToolVersion = toolVersion()
+					}
 
 					// Write results.
-					if err := w.WriteReconcilers(reconcilers); err != nil {
+					if err := w.WriteRepo(repo, reconcilers); err != nil {
 						log.Printf("Error writing results: %v", err)
+						recordError(repo, models.PhaseWrite, err)
+					}
+					for _, r := range reconcilers {
+						acc.Add(r)
 					}
-					mutex.Lock() // Just in case, wait times are so divergent it won't really matter
-					allReconcilers = append(allReconcilers, reconcilers...)
-					mutex.Unlock()
 
 					// Clean up clone if not keeping.
-					if !keepClones {
-						if err := os.RemoveAll(localPath); err != nil {
-							log.Printf("Warning: failed to remove %s: %v", localPath, err)
+					if cloned && !keepClones {
+						if err := os.RemoveAll(repo.LocalPath); err != nil {
+							log.Printf("Warning: failed to remove %s: %v", repo.LocalPath, err)
 						}
 					}
 				}()
 			}
 			wg.Wait()
+			wallClock := now().Sub(start)
+
+			// Write timing sidecar if requested.
+			if timingFile != "" {
+				if err := output.WriteTimings(timingFile, timings, wallClock); err != nil {
+					log.Printf("Error writing timings: %v", err)
+				}
+			}
+
+			// Write coverage sidecar if requested.
+			if covFile != "" {
+				if err := output.WriteCoverage(covFile, coverage); err != nil {
+					log.Printf("Error writing coverage: %v", err)
+				}
+			}
+
+			// Write error report if requested.
+			if errorsFile != "" {
+				if err := output.WriteErrors(errorsFile, repoErrors); err != nil {
+					log.Printf("Error writing error report: %v", err)
+				}
+			}
+
 			// Print summary.
-			summary := output.GenerateSummary(allReconcilers, 10)
-			output.PrintSummary(os.Stderr, summary)
+			if !quiet {
+				summary := acc.Finalize()
+				summary.AddTimings(timings, wallClock, 10)
+				summary.AddCoverage(coverage)
+				output.PrintSummary(os.Stderr, summary)
+			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&reposFile, "repos", "r", "", "File with repo URLs (one per line)")
+	cmd.Flags().StringVarP(&reposFile, "repos", "r", "", "File with repo URLs (one per line), or a .json/.yaml manifest")
 	cmd.Flags().StringSliceVar(&repoURLs, "repo", nil, "Individual repo URL(s) to analyze")
+	cmd.Flags().StringSliceVar(&localPaths, "path", nil, "Local repository checkout(s) to analyze without cloning")
+	cmd.Flags().StringSliceVar(&changed, "changed-files", nil, "Only analyze Reconcile functions in these files (relative to the --path repo root)")
+	cmd.Flags().StringVar(&diffBase, "diff-base", "", "Only analyze Reconcile functions in files changed since this git ref (--path repo only)")
+	cmd.Flags().StringSliceVar(&archives, "archive", nil, "Source archive(s) (.tar.gz, .tgz or .zip) to extract into the work dir and analyze")
+	cmd.Flags().IntVar(&minStars, "min-stars", 0, "Skip repos from --repos with fewer stars (--repo and --path are always analyzed)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only count packages and Reconcile functions per repo; no detection or output")
+	cmd.Flags().BoolVar(&listRecs, "list-reconcilers", false, "Print discovered reconcilers (id, file, line, receiver) as JSONL to stdout; no detection")
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (JSONL format, default: stdout)")
+	cmd.Flags().BoolVar(&compress, "gzip", false, "Gzip-compress JSONL output (implied by a .gz --output path)")
+	cmd.Flags().BoolVar(&explode, "explode-signals", false, "Write one JSONL record per signal (with reconciler_id, repo, file, reconciler_score, classification) instead of per reconciler")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Write one JSONL file per repo (owner__name.jsonl) into this directory")
 	cmd.Flags().StringVar(&workDir, "work-dir", "./repos", "Directory for cloning repos")
 	cmd.Flags().BoolVar(&keepClones, "keep-clones", false, "Keep cloned repos after analysis")
+	cmd.Flags().IntVar(&cloneDepth, "clone-depth", 1, "History depth of clones (0 = full history)")
+	cmd.Flags().StringVar(&cloneRef, "clone-ref", "", "Branch or tag to clone instead of the default branch")
+	cmd.Flags().BoolVar(&submodules, "recurse-submodules", false, "Also clone git submodules; large ones can be excluded with .surveyignore")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress the summary and per-repo progress logs (errors are still logged)")
 	cmd.Flags().Int32Var(&numWorkers, "num-workers", 3, "Number of workers")
+	cmd.Flags().IntVar(&fnWorkers, "func-workers", 0, "Reconcile functions analyzed concurrently within a repo (0: GOMAXPROCS)")
+	cmd.Flags().BoolVar(&inclTests, "include-tests", false, "Also analyze Reconcile functions in _test.go files")
+	cmd.Flags().BoolVar(&strictSig, "strict-signature", false, "Only match Reconcile methods whose types resolve exactly to ctrl.Request and ctrl.Result")
+	cmd.Flags().IntVar(&snippetLen, "snippet-length", analyzer.DefaultSnippetLength, "Maximum snippet length in bytes (0 = no truncation)")
+	cmd.Flags().BoolVar(&explain, "explain", false, "Attach a decision trace (signal scores, running total, threshold) to every reconciler")
+	cmd.Flags().StringSliceVar(&neutral, "neutral-kinds", analyzer.DefaultNeutralKinds, "Listed kinds whose List signals score 0 (diagnostic reads such as Events)")
+	cmd.Flags().BoolVar(&formatSnip, "format-snippets", false, "Keep snippets gofmt-formatted and multi-line instead of compacted")
+	cmd.Flags().StringVar(&inclRecv, "include-receiver", "", "Only analyze reconcilers whose receiver type matches this regex")
+	cmd.Flags().StringVar(&exclRecv, "exclude-receiver", "", "Skip reconcilers whose receiver type matches this regex")
+	cmd.Flags().StringVar(&skipPkg, "skip-package-regex", "", "Skip packages whose import path matches this regex")
+	cmd.Flags().StringVar(&subdir, "subdir", "", "Analyze only the module in this directory of each repo, e.g. operator")
+	cmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Reuse results of cloned repos from this directory when their commit and the analysis options are unchanged")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Ignore --cache-dir, e.g. when it is set in a --config file")
+	cmd.Flags().StringVar(&linkBase, "link-base", "", "Add a blob permalink to every signal under this URL base (default "+output.DefaultLinkBase+" when given without a value)")
+	cmd.Flags().Lookup("link-base").NoOptDefVal = output.DefaultLinkBase
+	cmd.Flags().StringVar(&timingFile, "timing-output", "", "Write per-repo clone/analyze timings to this JSON file")
+	cmd.Flags().StringVar(&errorsFile, "errors-file", "", "Write a JSONL record {repo, phase, error} for every failed repo to this file")
+	cmd.Flags().StringVar(&covFile, "coverage-output", "", "Write per-repo package/Reconcile function coverage to this JSON file")
 
 	return cmd
 }
 
+// filterByStars drops repositories with fewer than minStars stars.
+func filterByStars(repos []models.Repository, minStars int) []models.Repository {
+	var kept []models.Repository
+	for _, repo := range repos {
+		if repo.Stars < minStars {
+			log.Printf("Skipping %s: %d stars (below --min-stars=%d)", repo.URL, repo.Stars, minStars)
+			continue
+		}
+		kept = append(kept, repo)
+	}
+	return kept
+}
+
+// runDryRun prints package and Reconcile function counts for each repo.
+func runDryRun(a *analyzer.Analyzer, repos []models.Repository, workDir string, clone cloneOptions, verbose, keepClones bool) error {
+	for _, repo := range repos {
+		repo, cloned, err := prepareRepo(repo, workDir, clone, verbose)
+		if err != nil {
+			log.Printf("Error cloning %s: %v", repo.URL, err)
+			continue
+		}
+
+		inv, err := a.Inventory(repo)
+		if err != nil {
+			log.Printf("Error loading %s: %v", repo.URL, err)
+		} else {
+			fmt.Printf("%s: %d packages (%d failed to load, %s), %d Reconcile functions\n",
+				inv.Repo, inv.Packages, inv.PackageErrors, inv.LoadQuality, inv.Reconcilers)
+		}
+
+		if cloned && !keepClones {
+			if err := os.RemoveAll(repo.LocalPath); err != nil {
+				log.Printf("Warning: failed to remove %s: %v", repo.LocalPath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runListReconcilers prints every discovered Reconcile function as JSONL,
+// without pattern detection.
+func runListReconcilers(a *analyzer.Analyzer, repos []models.Repository, workDir string, clone cloneOptions, verbose, keepClones bool) error {
+	enc := json.NewEncoder(os.Stdout)
+	for _, repo := range repos {
+		repo, cloned, err := prepareRepo(repo, workDir, clone, verbose)
+		if err != nil {
+			log.Printf("Error cloning %s: %v", repo.URL, err)
+			continue
+		}
+
+		refs, err := a.ListReconcilers(repo)
+		if err != nil {
+			log.Printf("Error loading %s: %v", repo.URL, err)
+		}
+		for _, ref := range refs {
+			if err := enc.Encode(ref); err != nil {
+				return fmt.Errorf("failed to write reconciler: %w", err)
+			}
+		}
+
+		if cloned && !keepClones {
+			if err := os.RemoveAll(repo.LocalPath); err != nil {
+				log.Printf("Warning: failed to remove %s: %v", repo.LocalPath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // reportCmd generates reports from analysis results.
 func reportCmd() *cobra.Command {
 	var (
-		inputFile string
-		topN      int
+		inputFile  string
+		topN       int
+		format     string
+		excludePkg string
 	)
 
 	cmd := &cobra.Command{
@@ -186,33 +545,360 @@ func reportCmd() *cobra.Command {
 
 Examples:
   # Generate report from results file
-  k8s-controller-survey report --input=results.jsonl`,
+  k8s-controller-survey report --input=results.jsonl
+
+  # Emit the summary as JSON
+  k8s-controller-survey report --input=results.jsonl --format=json
+
+  # Leave vendored and API-package reconcilers out
+  k8s-controller-survey report --input=results.jsonl --exclude-pkg='/vendor/|/api/'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var excludePkgRe *regexp.Regexp
+			if excludePkg != "" {
+				re, err := regexp.Compile(excludePkg)
+				if err != nil {
+					return fmt.Errorf("invalid --exclude-pkg pattern: %w", err)
+				}
+				excludePkgRe = re
+			}
+
 			// Load reconcilers from file.
 			reconcilers, err := loadReconcilersFromFile(inputFile)
 			if err != nil {
 				return fmt.Errorf("failed to load results: %w", err)
 			}
 
+			if excludePkgRe != nil {
+				reconcilers = output.ExcludePackages(reconcilers, excludePkgRe)
+			}
+
 			// Generate summary.
 			summary := output.GenerateSummary(reconcilers, topN)
 
 			// Print summary.
-			output.PrintSummary(os.Stdout, summary)
+			switch format {
+			case "text":
+				output.PrintSummary(os.Stdout, summary)
+			case "json":
+				return output.PrintSummaryJSON(os.Stdout, summary)
+			default:
+				return fmt.Errorf("unknown format %q (expected text or json)", format)
+			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input JSONL file with analysis results")
+	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input JSONL file with analysis results (optionally gzipped)")
 	cmd.Flags().IntVar(&topN, "top", 10, "Number of top reconcilers to show")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.Flags().StringVar(&excludePkg, "exclude-pkg", "", "Leave out reconcilers whose receiver_pkg matches this regex")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+// calibrateCmd evaluates a scoring profile against labeled results.
+func calibrateCmd() *cobra.Command {
+	var (
+		inputFile   string
+		profileFile string
+		format      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "calibrate",
+		Short: "Evaluate a scoring profile against labeled reconcilers",
+		Long: `Re-classify reconcilers carrying a human-assigned "label" field under a
+scoring profile and report accuracy and a confusion matrix.
+
+Examples:
+  # Evaluate the built-in scores
+  k8s-controller-survey calibrate --input=labeled.jsonl
+
+  # Evaluate a custom profile
+  k8s-controller-survey calibrate --input=labeled.jsonl --profile=profile.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile := models.DefaultProfile()
+			if profileFile != "" {
+				p, err := analyzer.LoadProfile(profileFile)
+				if err != nil {
+					return fmt.Errorf("failed to load profile: %w", err)
+				}
+				profile = p
+			}
+
+			reconcilers, err := loadReconcilersFromFile(inputFile)
+			if err != nil {
+				return fmt.Errorf("failed to load results: %w", err)
+			}
+
+			cal := output.Calibrate(reconcilers, profile)
+			if cal.Labeled == 0 {
+				return fmt.Errorf("no labeled reconcilers in %s", inputFile)
+			}
+
+			switch format {
+			case "text":
+				output.PrintCalibration(os.Stdout, cal)
+			case "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(cal)
+			default:
+				return fmt.Errorf("unknown format %q (expected text or json)", format)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input JSONL file with labeled analysis results")
+	cmd.Flags().StringVar(&profileFile, "profile", "", "Scoring profile (JSON or YAML); defaults to the built-in scores")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+func signalsCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "signals",
+		Short: "List the signal types the analyzer can emit",
+		Long: `Print the signal catalog: every signal type with its default score,
+category and description.
+
+Examples:
+  # Print the catalog as a table
+  k8s-controller-survey signals
+
+  # Print the catalog as JSON
+  k8s-controller-survey signals --format=json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch format {
+			case "text":
+				output.PrintSignalCatalog(os.Stdout, models.SignalCatalog)
+			case "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(models.SignalCatalog)
+			default:
+				return fmt.Errorf("unknown format %q (expected text or json)", format)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// trendCmd compares classification percentages across result snapshots.
+func trendCmd() *cobra.Command {
+	var (
+		inputs []string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Compare classification percentages across result files",
+		Long: `Print the percentage of reconcilers per classification for each of
+several JSONL result files, e.g. dated snapshots of the same survey. Each
+--input may be prefixed with a label as label=path; the path is used otherwise.
+
+Examples:
+  k8s-controller-survey trend --input=2024-01=jan.jsonl --input=2024-06=jun.jsonl
+  k8s-controller-survey trend --input=old.jsonl --input=new.jsonl --format=json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var points []output.TrendPoint
+			for _, input := range inputs {
+				label, path := input, input
+				if i := strings.Index(input, "="); i >= 0 {
+					label, path = input[:i], input[i+1:]
+				}
+
+				reconcilers, err := loadReconcilersFromFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to load results from %s: %w", path, err)
+				}
+				points = append(points, output.NewTrendPoint(label, output.GenerateSummary(reconcilers, 0)))
+			}
+
+			switch format {
+			case "text":
+				output.PrintTrend(os.Stdout, points)
+			case "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(points)
+			default:
+				return fmt.Errorf("unknown format %q (expected text or json)", format)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&inputs, "input", "i", nil, "Input JSONL file, optionally as label=path (repeatable)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+// mergeCmd combines sharded result files into one.
+func mergeCmd() *cobra.Command {
+	var (
+		inputs     []string
+		outputFile string
+		compress   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "merge",
+		Short: "Merge JSONL result files, de-duplicating by reconciler ID",
+		Long: `Concatenate several JSONL result files, e.g. from analysis sharded across
+machines, into one. Each reconciler ID is kept once; IDs found with differing
+content in different inputs are reported as conflicts and the first copy wins.
+An --input directory, as written by analyze --output-dir, contributes all of
+its .jsonl and .jsonl.gz files.
+
+Examples:
+  k8s-controller-survey merge --input=shard1.jsonl --input=shard2.jsonl --output=results.jsonl
+  k8s-controller-survey merge --input=results/ --output=results.jsonl.gz`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var paths []string
+			for _, input := range inputs {
+				expanded, err := resultFiles(input)
+				if err != nil {
+					return err
+				}
+				paths = append(paths, expanded...)
+			}
+
+			var sets []output.ResultSet
+			for _, path := range paths {
+				reconcilers, err := loadReconcilersFromFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to load results from %s: %w", path, err)
+				}
+				sets = append(sets, output.ResultSet{Source: path, Reconcilers: reconcilers})
+			}
+
+			merged, conflicts, err := output.Merge(sets)
+			if err != nil {
+				return err
+			}
+
+			w, err := output.NewWriter(outputFile, compress)
+			if err != nil {
+				return fmt.Errorf("failed to create output writer: %w", err)
+			}
+			if err := w.WriteReconcilers(merged); err != nil {
+				w.Close()
+				return fmt.Errorf("failed to write merged results: %w", err)
+			}
+			if err := w.Close(); err != nil {
+				return fmt.Errorf("failed to close output: %w", err)
+			}
+
+			output.PrintConflicts(os.Stderr, conflicts)
+			log.Printf("Merged %d files into %d reconcilers (%d conflicts)", len(paths), len(merged), len(conflicts))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&inputs, "input", "i", nil, "Input JSONL file or directory of them (repeatable)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output JSONL file (default: stdout)")
+	cmd.Flags().BoolVar(&compress, "gzip", false, "Gzip-compress the output (implied by a .gz --output path)")
 	cmd.MarkFlagRequired("input")
 
 	return cmd
 }
 
-// loadReposFromFile loads repository URLs from a file.
+// goldenCmd checks detector fixtures against their golden files.
+func goldenCmd() *cobra.Command {
+	var (
+		dir    string
+		update bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "golden",
+		Short: "Check detector fixtures against their expected signals",
+		Long: `Analyze each .go fixture in a directory on its own and compare the
+reconcilers and signals found against the fixture's sibling .golden JSON file.
+Exits non-zero on any difference. Use --update to regenerate the golden files
+after an intended detector change, and review the diff.
+
+Examples:
+  k8s-controller-survey golden
+  k8s-controller-survey golden --dir=pkg/analyzer/testdata/fixtures --update`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a := analyzer.NewAnalyzer(os.TempDir(), false)
+			mismatches, err := a.CheckGoldens(dir, update)
+			if err != nil {
+				return err
+			}
+
+			for _, m := range mismatches {
+				if m.Want == "" {
+					fmt.Fprintf(os.Stderr, "%s: no golden file\n", m.Fixture)
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "%s: differs from golden\n--- want\n%s+++ got\n%s", m.Fixture, m.Want, m.Got)
+			}
+			if len(mismatches) > 0 {
+				return fmt.Errorf("%d fixtures differ from their golden files (rerun with --update if intended)", len(mismatches))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "pkg/analyzer/testdata/fixtures", "Directory of .go fixtures and .golden files")
+	cmd.Flags().BoolVar(&update, "update", false, "Rewrite golden files from the current analysis")
+
+	return cmd
+}
+
+// resultFiles expands path to the result files it names: itself, or the
+// .jsonl and .jsonl.gz files of a directory in name order.
+func resultFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && (strings.HasSuffix(e.Name(), ".jsonl") || strings.HasSuffix(e.Name(), ".jsonl.gz")) {
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+// loadReposFromFile loads repository URLs from a file. Files with a .json,
+// .yaml or .yml extension are read as structured manifests, anything else
+// as one URL per line.
 func loadReposFromFile(path string) ([]models.Repository, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return loadReposFromManifest(path)
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -228,6 +914,7 @@ func loadReposFromFile(path string) ([]models.Repository, error) {
 			continue
 		}
 
+		line = analyzer.NormalizeRepoURL(line)
 		owner, name := analyzer.ParseRepoURL(line)
 		repos = append(repos, models.Repository{
 			URL:    line,
@@ -244,9 +931,43 @@ func loadReposFromFile(path string) ([]models.Repository, error) {
 	return repos, nil
 }
 
-// loadReconcilersFromFile loads reconcilers from a JSONL file.
+// loadReposFromManifest loads an array of repositories from a JSON or YAML
+// manifest, preserving fields such as stars and source.
+func loadReposFromManifest(path string) ([]models.Repository, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []models.Repository
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(data, &repos)
+	} else {
+		err = yaml.Unmarshal(data, &repos)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+
+	for i := range repos {
+		if repos[i].URL == "" {
+			return nil, fmt.Errorf("manifest %s: entry %d has no url", path, i)
+		}
+		repos[i].URL = analyzer.NormalizeRepoURL(repos[i].URL)
+		if repos[i].Owner == "" || repos[i].Name == "" {
+			repos[i].Owner, repos[i].Name = analyzer.ParseRepoURL(repos[i].URL)
+		}
+		if repos[i].Source == "" {
+			repos[i].Source = "manifest"
+		}
+	}
+
+	return repos, nil
+}
+
+// loadReconcilersFromFile loads reconcilers from a (possibly gzipped) JSONL file.
 func loadReconcilersFromFile(path string) ([]models.Reconciler, error) {
-	file, err := os.Open(path)
+	file, err := output.OpenResults(path)
 	if err != nil {
 		return nil, err
 	}
@@ -282,8 +1003,163 @@ func loadReconcilersFromFile(path string) ([]models.Reconciler, error) {
 	return reconcilers, nil
 }
 
+// localRepo builds a Repository for a local checkout.
+func localRepo(path string) (models.Repository, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return models.Repository{}, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	if info, err := os.Stat(absPath); err != nil || !info.IsDir() {
+		return models.Repository{}, fmt.Errorf("path %s is not a directory", path)
+	}
+
+	return models.Repository{
+		URL:       absPath,
+		Owner:     "local",
+		Name:      filepath.Base(absPath),
+		Source:    "path",
+		LocalPath: absPath,
+	}, nil
+}
+
+// prepareRepo makes sure the repository is available locally, cloning it
+// (or extracting its archive) unless it already has a LocalPath. It reports
+// whether a clone or extraction was made.
+func prepareRepo(repo models.Repository, workDir string, clone cloneOptions, verbose bool) (models.Repository, bool, error) {
+	if repo.LocalPath != "" {
+		return repo, false, nil
+	}
+
+	if repo.Archive != "" {
+		localPath, err := extractArchive(repo, workDir, verbose)
+		if err != nil {
+			return repo, false, err
+		}
+		repo.LocalPath = localPath
+		return repo, true, nil
+	}
+
+	localPath, err := cloneRepo(repo.URL, workDir, clone, verbose)
+	if err != nil {
+		return repo, false, err
+	}
+	repo.LocalPath = localPath
+
+	return repo, true, nil
+}
+
+// diffFiles lists the files changed in the repository at path since ref,
+// including uncommitted changes and untracked files, relative to path. Only
+// files below path are listed when it is a subdirectory of the work tree.
+func diffFiles(path, ref string) ([]string, error) {
+	changed, err := gitLines(path, "diff", "--name-only", "--relative", ref)
+	if err != nil {
+		return nil, fmt.Errorf("git diff against %s failed: %w", ref, err)
+	}
+	untracked, err := gitLines(path, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", err)
+	}
+	return append(changed, untracked...), nil
+}
+
+// gitLines runs git in dir and returns the non-empty lines of its output.
+func gitLines(dir string, args ...string) ([]string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// analyzeCached analyzes repo, or loads its results from cache if they were
+// stored for the same commit, tool build and analysis options. Only
+// cloned repos are cached: a local checkout may have uncommitted changes.
+func analyzeCached(a *analyzer.Analyzer, repo models.Repository, cache *output.ResultCache, cloned, verbose bool) ([]models.Reconciler, models.RepoInventory, error) {
+	var key string
+	if cache != nil && cloned {
+		head, version := headCommit(repo.LocalPath), cacheVersion()
+		if head != "" && version != "" {
+			key = output.CacheKey(version, repo.URL, head, a.Fingerprint())
+		}
+	}
+	if key == "" {
+		return a.AnalyzeRepoWithInventory(repo)
+	}
+
+	entry, err := cache.Load(key)
+	if err != nil {
+		log.Printf("Warning: ignoring cached results of %s: %v", repo.URL, err)
+	}
+	if entry != nil {
+		if verbose {
+			log.Printf("Using cached results for %s", repo.URL)
+		}
+		return entry.Reconcilers, entry.Inventory, nil
+	}
+
+	reconcilers, inv, err := a.AnalyzeRepoWithInventory(repo)
+	if err != nil {
+		return reconcilers, inv, err
+	}
+	if err := cache.Store(key, output.CacheEntry{Reconcilers: reconcilers, Inventory: inv}); err != nil {
+		log.Printf("Warning: failed to cache results of %s: %v", repo.URL, err)
+	}
+	return reconcilers, inv, nil
+}
+
+// headCommit returns the commit checked out at path, or "" if it cannot
+// be determined.
+func headCommit(path string) string {
+	out, err := exec.Command("git", "-C", path, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// cloneOptions controls how repositories are cloned. Clones always run the
+// git CLI; there is no in-process (go-git) backend.
+type cloneOptions struct {
+	// Depth limits the fetched history; 0 clones the full history.
+	Depth int
+
+	// Ref is the branch or tag to check out; empty uses the default branch.
+	Ref string
+
+	// RecurseSubmodules also clones submodules, as shallow as the
+	// superproject, so controllers vendored as submodules are analyzed.
+	// It maps to git clone --recurse-submodules (and --shallow-submodules).
+	RecurseSubmodules bool
+}
+
+// cloneArgs builds the git arguments to clone repoURL into localPath.
+func cloneArgs(repoURL, localPath string, opts cloneOptions) []string {
+	args := []string{"clone"}
+	if opts.Depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", opts.Depth))
+	}
+	if opts.Ref != "" {
+		args = append(args, "--branch", opts.Ref)
+	}
+	if opts.RecurseSubmodules {
+		args = append(args, "--recurse-submodules")
+		if opts.Depth > 0 {
+			args = append(args, "--shallow-submodules")
+		}
+	}
+	return append(args, repoURL, localPath)
+}
+
 // cloneRepo clones a repository to the work directory.
-func cloneRepo(repoURL, workDir string, verbose bool) (string, error) {
+func cloneRepo(repoURL, workDir string, opts cloneOptions, verbose bool) (string, error) {
 	// Parse repo URL to get owner and name.
 	owner, name := analyzer.ParseRepoURL(repoURL)
 	if owner == "" || name == "" {
@@ -309,8 +1185,7 @@ func cloneRepo(repoURL, workDir string, verbose bool) (string, error) {
 		log.Printf("Cloning %s to %s", repoURL, localPath)
 	}
 
-	// Clone with depth 1 for speed.
-	cmd := exec.Command("git", "clone", "--depth=1", repoURL, localPath)
+	cmd := exec.Command("git", cloneArgs(repoURL, localPath, opts)...)
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 	if verbose {
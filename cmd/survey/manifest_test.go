@@ -0,0 +1,66 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestLoadReposFromManifest(t *testing.T) {
+	want := []models.Repository{
+		{URL: "https://github.com/fluxcd/flux2", Owner: "fluxcd", Name: "flux2", Stars: 6000, Source: "cncf"},
+		{URL: "https://github.com/acme/widgets", Owner: "acme", Name: "widgets", Source: "manifest"},
+	}
+	tests := []struct {
+		name, file, content string
+		wantErr             bool
+	}{
+		{
+			name: "yaml",
+			file: "repos.yaml",
+			content: `- url: https://github.com/fluxcd/flux2
+  stars: 6000
+  source: cncf
+- url: acme/widgets
+`,
+		},
+		{
+			name:    "json",
+			file:    "repos.json",
+			content: `[{"url": "fluxcd/flux2", "stars": 6000, "source": "cncf"}, {"url": "github.com/acme/widgets"}]`,
+		},
+		{
+			name:    "entry without url",
+			file:    "repos.yaml",
+			content: "- name: widgets\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			file:    "repos.json",
+			content: "{",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			writeFile(t, path, tt.content)
+			got, err := loadReposFromManifest(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("loadReposFromManifest() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("loadReposFromManifest() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestResultFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.jsonl", "b.jsonl.gz", "notes.txt", "nested/c.jsonl"} {
+		writeFile(t, filepath.Join(dir, name), "")
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "file", path: filepath.Join(dir, "notes.txt"), want: []string{filepath.Join(dir, "notes.txt")}},
+		{name: "directory", path: dir, want: []string{filepath.Join(dir, "a.jsonl"), filepath.Join(dir, "b.jsonl.gz")}},
+		{name: "missing", path: filepath.Join(dir, "missing.jsonl"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resultFiles(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resultFiles() error = %v, want error %t", err, tt.wantErr)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resultFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeCmd(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "shards", "1.jsonl"), `{"id":"a#x.go#1","score":1}`+"\n"+`{"id":"a#y.go#2","score":2}`+"\n")
+	writeFile(t, filepath.Join(dir, "shards", "2.jsonl"), `{"id":"a#y.go#2","score":2}`+"\n")
+	writeFile(t, filepath.Join(dir, "3.jsonl"), `{"id":"a#z.go#3","score":3}`+"\n")
+	out := filepath.Join(dir, "merged.jsonl.gz")
+
+	cmd := mergeCmd()
+	cmd.SetArgs([]string{"-i", filepath.Join(dir, "shards"), "-i", filepath.Join(dir, "3.jsonl"), "-o", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := loadReconcilersFromFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range merged {
+		got = append(got, r.ID)
+	}
+	if want := []string{"a#x.go#1", "a#y.go#2", "a#z.go#3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("merged %v, want %v", got, want)
+	}
+}
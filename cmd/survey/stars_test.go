@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestFilterByStars(t *testing.T) {
+	repos := []models.Repository{
+		{URL: "a", Stars: 10},
+		{URL: "b", Stars: 500},
+		{URL: "c"},
+		{URL: "d", Stars: 100},
+	}
+	tests := []struct {
+		minStars int
+		want     []string
+	}{
+		{0, []string{"a", "b", "c", "d"}},
+		{100, []string{"b", "d"}},
+		{1000, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, repo := range filterByStars(repos, tt.minStars) {
+			got = append(got, repo.URL)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("filterByStars(%d) = %v, want %v", tt.minStars, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/output"
+)
+
+// captureStdout runs f and returns what it wrote to stdout.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	out, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	defer func(f *os.File) { os.Stdout = f }(os.Stdout)
+	os.Stdout = out
+
+	f()
+	data, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// snapshot writes reconcilers of the given classifications as JSONL.
+func snapshot(t *testing.T, path string, classes ...string) {
+	t.Helper()
+	var sb strings.Builder
+	for _, class := range classes {
+		sb.WriteString(`{"id":"x","classification":"` + class + `"}` + "\n")
+	}
+	writeFile(t, path, sb.String())
+}
+
+func TestTrendCmd(t *testing.T) {
+	dir := t.TempDir()
+	f1, f2, f3 := filepath.Join(dir, "jan.jsonl"), filepath.Join(dir, "feb.jsonl"), filepath.Join(dir, "mar.jsonl")
+	snapshot(t, f1, "sotw", "sotw", "sotw", "edge_triggered")
+	snapshot(t, f2, "sotw", "mostly_sotw")
+	snapshot(t, f3, "edge_triggered", "mostly_edge", "mostly_edge", "sotw", "sotw")
+
+	cmd := trendCmd()
+	cmd.SetArgs([]string{"--input=a=" + f1, "--input=" + f2, "--input=c=" + f3, "--format=json"})
+	stdout := captureStdout(t, func() {
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	var points []output.TrendPoint
+	if err := json.Unmarshal([]byte(stdout), &points); err != nil {
+		t.Fatalf("%v:\n%s", err, stdout)
+	}
+	want := []struct {
+		label       string
+		total       int
+		percentages map[string]float64
+	}{
+		{"a", 4, map[string]float64{"edge_triggered": 25, "mostly_edge": 0, "mostly_sotw": 0, "sotw": 75}},
+		{f2, 2, map[string]float64{"edge_triggered": 0, "mostly_edge": 0, "mostly_sotw": 50, "sotw": 50}},
+		{"c", 5, map[string]float64{"edge_triggered": 20, "mostly_edge": 40, "mostly_sotw": 0, "sotw": 40}},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+	for i, w := range want {
+		p := points[i]
+		if p.Label != w.label || p.TotalReconcilers != w.total || !reflect.DeepEqual(p.Percentages, w.percentages) {
+			t.Errorf("point %d = %s %d %v, want %s %d %v", i, p.Label, p.TotalReconcilers, p.Percentages, w.label, w.total, w.percentages)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+//go:build tui
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+	"github.com/rivo/tview"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	extraCommands = append(extraCommands, tuiCmd)
+}
+
+// tuiCmd browses analysis results interactively.
+func tuiCmd() *cobra.Command {
+	var inputFile string
+
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse analysis results interactively",
+		Long: `Browse JSONL analysis results in a terminal UI: pick a classification,
+select a reconciler and inspect its signals and snippets. Type in the filter
+field to restrict reconcilers to matching repositories.
+
+Keys: Tab/Shift-Tab move between panes, / focuses the filter, q quits.
+
+Examples:
+  k8s-controller-survey tui --input=results.jsonl`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reconcilers, err := loadReconcilersFromFile(inputFile)
+			if err != nil {
+				return fmt.Errorf("failed to load results: %w", err)
+			}
+			return newBrowser(reconcilers).app.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input JSONL file with analysis results")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+// browser is the state of the results TUI.
+type browser struct {
+	app     *tview.Application
+	filter  *tview.InputField
+	classes *tview.List
+	items   *tview.List
+	detail  *tview.TextView
+
+	all   []models.Reconciler
+	class string
+	shown []models.Reconciler
+}
+
+// newBrowser builds the TUI over reconcilers without starting it.
+func newBrowser(reconcilers []models.Reconciler) *browser {
+	b := &browser{
+		app:     tview.NewApplication(),
+		filter:  tview.NewInputField().SetLabel("Repo filter: "),
+		classes: tview.NewList().ShowSecondaryText(false),
+		items:   tview.NewList().ShowSecondaryText(false),
+		detail:  tview.NewTextView().SetDynamicColors(true).SetWrap(true),
+		all:     reconcilers,
+	}
+	if len(models.Classifications) > 0 {
+		b.class = models.Classifications[0]
+	}
+
+	b.classes.SetBorder(true).SetTitle("Classifications")
+	b.items.SetBorder(true).SetTitle("Reconcilers")
+	b.detail.SetBorder(true).SetTitle("Detail")
+
+	b.filter.SetChangedFunc(func(string) {
+		b.refresh()
+	})
+	b.filter.SetDoneFunc(func(tcell.Key) {
+		b.app.SetFocus(b.items)
+	})
+	b.classes.SetChangedFunc(func(index int, _, _ string, _ rune) {
+		if index < len(models.Classifications) {
+			b.class = models.Classifications[index]
+			b.refreshItems()
+		}
+	})
+	b.items.SetChangedFunc(func(index int, _, _ string, _ rune) {
+		b.showDetail(index)
+	})
+
+	panes := []tview.Primitive{b.filter, b.classes, b.items, b.detail}
+	b.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if b.app.GetFocus() == b.filter && event.Key() != tcell.KeyTab && event.Key() != tcell.KeyBacktab {
+			return event
+		}
+		switch {
+		case event.Key() == tcell.KeyTab:
+			b.app.SetFocus(panes[(b.focusIndex(panes)+1)%len(panes)])
+			return nil
+		case event.Key() == tcell.KeyBacktab:
+			b.app.SetFocus(panes[(b.focusIndex(panes)+len(panes)-1)%len(panes)])
+			return nil
+		case event.Rune() == '/':
+			b.app.SetFocus(b.filter)
+			return nil
+		case event.Rune() == 'q':
+			b.app.Stop()
+			return nil
+		}
+		return event
+	})
+
+	body := tview.NewFlex().
+		AddItem(b.classes, 24, 0, false).
+		AddItem(b.items, 0, 1, true).
+		AddItem(b.detail, 0, 2, false)
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(b.filter, 1, 0, false).
+		AddItem(body, 0, 1, true)
+	b.app.SetRoot(root, true).SetFocus(b.items)
+
+	b.refresh()
+	return b
+}
+
+// focusIndex returns the index of the focused pane, or 0.
+func (b *browser) focusIndex(panes []tview.Primitive) int {
+	focus := b.app.GetFocus()
+	for i, p := range panes {
+		if p == focus {
+			return i
+		}
+	}
+	return 0
+}
+
+// matches reports whether a reconciler passes the repo filter.
+func (b *browser) matches(r models.Reconciler) bool {
+	return strings.Contains(strings.ToLower(r.Repo), strings.ToLower(b.filter.GetText()))
+}
+
+// refresh rebuilds the classification counts and the reconciler list.
+func (b *browser) refresh() {
+	counts := make(map[string]int)
+	for _, r := range b.all {
+		if b.matches(r) {
+			counts[r.Classification]++
+		}
+	}
+
+	current := b.classes.GetCurrentItem()
+	b.classes.Clear()
+	for _, class := range models.Classifications {
+		b.classes.AddItem(fmt.Sprintf("%s (%d)", class, counts[class]), "", 0, nil)
+	}
+	b.classes.SetCurrentItem(current)
+
+	b.refreshItems()
+}
+
+// refreshItems lists the reconcilers of the selected classification,
+// highest score first.
+func (b *browser) refreshItems() {
+	b.shown = b.shown[:0]
+	for _, r := range b.all {
+		if r.Classification == b.class && b.matches(r) {
+			b.shown = append(b.shown, r)
+		}
+	}
+	sort.SliceStable(b.shown, func(i, j int) bool {
+		return b.shown[i].Score > b.shown[j].Score
+	})
+
+	b.items.Clear()
+	for _, r := range b.shown {
+		b.items.AddItem(fmt.Sprintf("%+3d  %s  %s", r.Score, r.Repo, r.ReceiverType), "", 0, nil)
+	}
+	b.showDetail(0)
+}
+
+// showDetail renders the reconciler at index of the shown list.
+func (b *browser) showDetail(index int) {
+	b.detail.Clear()
+	if index < 0 || index >= len(b.shown) {
+		return
+	}
+	r := b.shown[index]
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[yellow]%s[-]\n", tview.Escape(r.ID))
+	fmt.Fprintf(&sb, "%s:%d  %s.%s\n", tview.Escape(r.File), r.Line, tview.Escape(r.ReceiverPkg), tview.Escape(r.ReceiverType))
+	fmt.Fprintf(&sb, "Score: %d (%s)\n", r.Score, r.Classification)
+	if r.PrimaryType != "" {
+		fmt.Fprintf(&sb, "Primary type: %s\n", tview.Escape(r.PrimaryType))
+	}
+	for _, sig := range r.Signals {
+		fmt.Fprintf(&sb, "\n[green]%s[-] %+d  line %d\n", sig.Type, sig.Score, sig.Line)
+		fmt.Fprintf(&sb, "  %s\n", tview.Escape(sig.Description))
+		fmt.Fprintf(&sb, "  [gray]%s[-]\n", tview.Escape(sig.Snippet))
+	}
+	b.detail.SetText(sb.String())
+	b.detail.ScrollToBeginning()
+}
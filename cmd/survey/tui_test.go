@@ -0,0 +1,89 @@
+//go:build tui
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+	"github.com/rivo/tview"
+)
+
+func TestBrowserFilter(t *testing.T) {
+	b := newBrowser([]models.Reconciler{
+		{ID: "a", Repo: "github.com/acme/widgets", Classification: models.Classifications[0], Score: 1},
+		{ID: "b", Repo: "github.com/acme/gadgets", Classification: models.Classifications[0], Score: 5},
+		{ID: "c", Repo: "github.com/other/widgets", Classification: models.Classifications[0], Score: 3},
+		{ID: "d", Repo: "github.com/acme/widgets", Classification: models.Classifications[1], Score: 9},
+	})
+	tests := []struct {
+		name   string
+		filter string
+		want   []string
+	}{
+		{name: "no filter, highest score first", filter: "", want: []string{"b", "c", "a"}},
+		{name: "case-insensitive substring", filter: "ACME", want: []string{"b", "a"}},
+		{name: "no match", filter: "nope", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b.filter.SetText(tt.filter)
+			got := []string{}
+			for _, r := range b.shown {
+				got = append(got, r.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("shown = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrowserKeys(t *testing.T) {
+	b := newBrowser(nil)
+	capture := b.app.GetInputCapture()
+	key := func(k tcell.Key) *tcell.EventKey { return tcell.NewEventKey(k, 0, tcell.ModNone) }
+	char := func(r rune) *tcell.EventKey { return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone) }
+
+	tests := []struct {
+		name     string
+		event    *tcell.EventKey
+		focus    tview.Primitive
+		consumed bool
+	}{
+		{name: "tab", event: key(tcell.KeyTab), focus: b.detail, consumed: true},
+		{name: "tab wraps", event: key(tcell.KeyTab), focus: b.filter, consumed: true},
+		{name: "typing into the filter", event: char('q'), focus: b.filter},
+		{name: "tab out of the filter", event: key(tcell.KeyTab), focus: b.classes, consumed: true},
+		{name: "backtab", event: key(tcell.KeyBacktab), focus: b.filter, consumed: true},
+		{name: "backtab wraps", event: key(tcell.KeyBacktab), focus: b.detail, consumed: true},
+		{name: "slash", event: char('/'), focus: b.filter, consumed: true},
+		{name: "enter in the filter", event: key(tcell.KeyEnter), focus: b.filter},
+	}
+	if b.app.GetFocus() != b.items {
+		t.Fatal("reconciler list not focused initially")
+	}
+	for _, tt := range tests {
+		got := capture(tt.event)
+		if consumed := got == nil; consumed != tt.consumed {
+			t.Errorf("%s: consumed = %t, want %t", tt.name, consumed, tt.consumed)
+		}
+		if !tt.consumed && got != tt.event {
+			t.Errorf("%s: passed on %v, want the event itself", tt.name, got)
+		}
+		if b.app.GetFocus() != tt.focus {
+			t.Errorf("%s: focus moved to %T, want %T", tt.name, b.app.GetFocus(), tt.focus)
+		}
+	}
+
+	// Outside the filter, other keys reach the focused pane and q quits.
+	b.app.SetFocus(b.items)
+	if ev := char('j'); capture(ev) != ev {
+		t.Error("j not passed on to the reconciler list")
+	}
+	if capture(char('q')) != nil {
+		t.Error("q not consumed outside the filter")
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// Build metadata, injected at build time with
+// -ldflags "-X main.version=... -X main.commit=... -X main.date=...".
+var (
+	version = ""
+	commit  = ""
+	date    = ""
+)
+
+// toolVersion returns the injected version, else the module version
+// recorded by go install, else "dev".
+func toolVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// binaryHash is the SHA-256 of the running executable, or "" if unreadable.
+var binaryHash = sync.OnceValue(func() string {
+	path, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+})
+
+// cacheVersion identifies the build in cache keys. Dev and +dirty builds
+// share a version across code changes, so they are identified by a hash of
+// the running binary instead; "" if it cannot be read.
+func cacheVersion() string {
+	v := toolVersion()
+	if v == "dev" || strings.Contains(v, "+dirty") {
+		return binaryHash()
+	}
+	return v
+}
+
+// versionCmd prints the build metadata.
+func versionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the version, commit and build date",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(cmd.OutOrStdout(), "version: %s\n", toolVersion())
+			if commit != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "commit:  %s\n", commit)
+			}
+			if date != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "date:    %s\n", date)
+			}
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestToolVersion(t *testing.T) {
+	defer func(v string) { version = v }(version)
+
+	version = ""
+	if got := toolVersion(); got == "" {
+		t.Error("toolVersion() is empty without an injected version")
+	}
+	version = "v1.2.3"
+	if got := toolVersion(); got != "v1.2.3" {
+		t.Errorf("toolVersion() = %q, want the injected v1.2.3", got)
+	}
+}
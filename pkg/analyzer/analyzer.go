@@ -5,8 +5,13 @@ import (
 	"go/token"
 	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/rg0now/k8s-controller-survey/pkg/models"
 	"golang.org/x/tools/go/packages"
@@ -16,30 +21,99 @@ import (
 type Analyzer struct {
 	workDir string
 	verbose bool
+
+	// IncludeTests loads test packages and analyzes Reconcile functions in _test.go files.
+	IncludeTests bool
+
+	// StrictSignature only accepts Reconcile methods taking ctrl.Request and
+	// returning ctrl.Result, as resolved by the type checker.
+	StrictSignature bool
+
+	// SnippetLength caps signal snippet length in bytes; 0 disables truncation.
+	SnippetLength int
+
+	// FormatSnippets keeps snippets gofmt-formatted and multi-line.
+	FormatSnippets bool
+
+	// NeutralKinds are listed kinds that do not score; nil uses
+	// DefaultNeutralKinds.
+	NeutralKinds []string
+
+	// Explain attaches a DecisionTrace to every reconciler.
+	Explain bool
+
+	// Detectors are custom detectors run in addition to the registered ones.
+	Detectors []Detector
+
+	// IncludeReceiver, if set, keeps only reconcilers whose receiver type matches.
+	IncludeReceiver *regexp.Regexp
+
+	// ExcludeReceiver, if set, drops reconcilers whose receiver type matches.
+	ExcludeReceiver *regexp.Regexp
+
+	// SkipPackage, if set, drops loaded packages whose import path matches.
+	SkipPackage *regexp.Regexp
+
+	// ChangedFiles, if set, keeps only Reconcile functions in these files,
+	// given relative to the repository root.
+	ChangedFiles []string
+
+	// Subdir, if set, is the directory below each repository root, such as
+	// "operator", holding the module to analyze. Only it is loaded; file
+	// paths in results stay relative to the repository root.
+	Subdir string
+
+	// FuncWorkers caps how many Reconcile functions of one repository are
+	// analyzed concurrently; 0 means runtime.GOMAXPROCS(0).
+	FuncWorkers int
+
+	// overlay maps absolute file paths to in-memory contents that replace
+	// or add to the files on disk (see AnalyzeSource).
+	overlay map[string][]byte
 }
 
 // NewAnalyzer creates a new Analyzer.
 func NewAnalyzer(workDir string, verbose bool) *Analyzer {
 	return &Analyzer{
-		workDir: workDir,
-		verbose: verbose,
+		workDir:       workDir,
+		verbose:       verbose,
+		SnippetLength: DefaultSnippetLength,
 	}
 }
 
 // AnalyzeRepo analyzes a single repository and returns all found reconcilers.
 func (a *Analyzer) AnalyzeRepo(repo models.Repository) ([]models.Reconciler, error) {
+	reconcilers, _, err := a.AnalyzeRepoWithInventory(repo)
+	return reconcilers, err
+}
+
+// AnalyzeRepoWithInventory is like AnalyzeRepo but also returns the
+// repository's coverage: packages loaded, packages that failed to load and
+// Reconcile functions found. The inventory is filled as far as analysis got,
+// even on error.
+func (a *Analyzer) AnalyzeRepoWithInventory(repo models.Repository) ([]models.Reconciler, models.RepoInventory, error) {
+	inv := models.RepoInventory{Repo: repo.URL}
+
 	if a.verbose {
 		log.Printf("Analyzing repository: %s", repo.URL)
 	}
 
 	// Load packages.
-	pkgs, err := a.loadPackages(repo.LocalPath)
+	pkgs, failed, quality, err := a.loadPackages(a.moduleDir(repo))
 	if err != nil {
-		return nil, fmt.Errorf("failed to load packages: %w", err)
+		inv.LoadQuality = models.LoadQualityFailed
+		return nil, inv, fmt.Errorf("failed to load packages: %w", err)
+	}
+	inv.Packages = len(pkgs) + failed
+	inv.PackageErrors = failed
+	inv.LoadQuality = quality
+	a.addModuleInfo(&inv, a.moduleDir(repo))
+	if a.verbose && quality != models.LoadQualityFull {
+		log.Printf("Loaded %s with quality %s", repo.URL, quality)
 	}
 
 	if len(pkgs) == 0 {
-		return nil, fmt.Errorf("no packages found in repository")
+		return nil, inv, fmt.Errorf("no packages found in repository")
 	}
 
 	// Use the FileSet from the first package (they all share the same one).
@@ -52,79 +126,466 @@ func (a *Analyzer) AnalyzeRepo(repo models.Repository) ([]models.Reconciler, err
 
 	// Find Reconcile functions.
 	finder := NewReconcileFinder(fset)
-	reconcileFuncs := finder.FindReconcileFunctions(pkgs)
+	finder.IncludeTests = a.IncludeTests
+	finder.StrictSignature = a.StrictSignature
+	reconcileFuncs, err := findReconcileFuncs(finder, pkgs)
+	if err != nil {
+		return nil, inv, err
+	}
 
 	if a.verbose {
 		log.Printf("Found %d Reconcile functions in %s", len(reconcileFuncs), repo.URL)
 	}
 
-	// Analyze each Reconcile function.
-	var results []models.Reconciler
-	for _, recFunc := range reconcileFuncs {
-		reconciler, err := a.analyzeReconcileFunc(recFunc, repo, fset)
-		if err != nil {
-			if a.verbose {
-				log.Printf("Error analyzing Reconcile function: %v", err)
+	// Apply receiver type and changed file filters.
+	reconcileFuncs = a.filterReceivers(reconcileFuncs)
+	reconcileFuncs = a.filterChangedFiles(reconcileFuncs, repo.LocalPath, fset)
+	inv.Reconcilers = len(reconcileFuncs)
+
+	// Analyze each Reconcile function. They are independent and only read
+	// the shared packages and FileSet, so they run on a worker pool; results
+	// keep discovery order.
+	// Functions whose analysis fails are logged and counted in the
+	// inventory, so they do not silently drop out of the results.
+	analyzed := make([]*models.Reconciler, len(reconcileFuncs))
+	jobs := make(chan int)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < a.funcWorkers(len(reconcileFuncs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reconciler, err := a.analyzeReconcileFunc(reconcileFuncs[i], repo, fset)
+				if err != nil {
+					log.Printf("Error analyzing Reconcile function in %s: %v", repo.URL, err)
+					continue
+				}
+				reconciler.LoadQuality = quality
+				analyzed[i] = &reconciler
 			}
+		}()
+	}
+	for i := range reconcileFuncs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var results []models.Reconciler
+	for _, r := range analyzed {
+		if r != nil {
+			results = append(results, *r)
+		} else {
+			inv.AnalysisErrors++
+		}
+	}
+
+	return results, inv, nil
+}
+
+// moduleDir returns the directory to load a repository's packages from: its
+// root, or Subdir below it.
+func (a *Analyzer) moduleDir(repo models.Repository) string {
+	return filepath.Join(repo.LocalPath, filepath.FromSlash(a.Subdir))
+}
+
+// Fingerprint describes the options that change analysis results, such as
+// filters, snippet settings and detectors, for keying cached results.
+func (a *Analyzer) Fingerprint() string {
+	regexpString := func(re *regexp.Regexp) string {
+		if re == nil {
+			return ""
+		}
+		return re.String()
+	}
+	var detectors []string
+	for _, d := range append(RegisteredDetectors(), a.Detectors...) {
+		detectors = append(detectors, d.Name())
+	}
+	return strings.Join([]string{
+		fmt.Sprintf("tests=%t strict=%t snippet=%d format=%t explain=%t",
+			a.IncludeTests, a.StrictSignature, a.SnippetLength, a.FormatSnippets, a.Explain),
+		"neutral=" + strings.Join(a.NeutralKinds, ","),
+		"include=" + regexpString(a.IncludeReceiver),
+		"exclude=" + regexpString(a.ExcludeReceiver),
+		"skip=" + regexpString(a.SkipPackage),
+		"changed=" + strings.Join(a.ChangedFiles, ","),
+		"subdir=" + a.Subdir,
+		"detectors=" + strings.Join(detectors, ","),
+	}, "\n")
+}
+
+// funcWorkers returns the number of workers to analyze n Reconcile
+// functions with.
+func (a *Analyzer) funcWorkers(n int) int {
+	workers := a.FuncWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return min(workers, n)
+}
+
+// filterReceivers applies the include/exclude receiver type patterns.
+func (a *Analyzer) filterReceivers(funcs []ReconcileFunc) []ReconcileFunc {
+	if a.IncludeReceiver == nil && a.ExcludeReceiver == nil {
+		return funcs
+	}
+
+	var kept []ReconcileFunc
+	for _, f := range funcs {
+		if a.IncludeReceiver != nil && !a.IncludeReceiver.MatchString(f.ReceiverType) {
+			continue
+		}
+		if a.ExcludeReceiver != nil && a.ExcludeReceiver.MatchString(f.ReceiverType) {
 			continue
 		}
-		results = append(results, reconciler)
+		kept = append(kept, f)
 	}
 
-	return results, nil
+	if a.verbose && len(kept) != len(funcs) {
+		log.Printf("Receiver filters dropped %d of %d Reconcile functions", len(funcs)-len(kept), len(funcs))
+	}
+
+	return kept
 }
 
-// loadPackages loads all Go packages from a repository.
-func (a *Analyzer) loadPackages(repoPath string) ([]*packages.Package, error) {
-	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
-			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports,
-		Dir: repoPath,
-		Env: append(os.Environ(), "GOFLAGS=-tags="),
+// filterChangedFiles keeps the Reconcile functions declared in ChangedFiles.
+func (a *Analyzer) filterChangedFiles(funcs []ReconcileFunc, repoPath string, fset *token.FileSet) []ReconcileFunc {
+	if len(a.ChangedFiles) == 0 {
+		return funcs
 	}
 
-	// Load all packages.
-	pkgs, err := packages.Load(cfg, "./...")
+	changed := make(map[string]bool, len(a.ChangedFiles))
+	for _, f := range a.ChangedFiles {
+		changed[filepath.ToSlash(filepath.Clean(f))] = true
+	}
+
+	var kept []ReconcileFunc
+	for _, f := range funcs {
+		if changed[repoRelPath(repoPath, fset.Position(f.Func.Pos()).Filename)] {
+			kept = append(kept, f)
+		}
+	}
+
+	if a.verbose {
+		log.Printf("Changed files kept %d of %d Reconcile functions", len(kept), len(funcs))
+	}
+
+	return kept
+}
+
+// Inventory loads a repository and counts its packages and Reconcile
+// functions without running pattern detection.
+func (a *Analyzer) Inventory(repo models.Repository) (models.RepoInventory, error) {
+	funcs, _, inv, err := a.discover(repo)
+	inv.Reconcilers = len(funcs)
+	return inv, err
+}
+
+// ListReconcilers loads a repository and returns where its Reconcile
+// functions are, without running pattern detection.
+func (a *Analyzer) ListReconcilers(repo models.Repository) ([]models.ReconcilerRef, error) {
+	funcs, fset, _, err := a.discover(repo)
 	if err != nil {
 		return nil, err
 	}
 
+	refs := make([]models.ReconcilerRef, 0, len(funcs))
+	for _, recFunc := range funcs {
+		relPath := repoRelPath(repo.LocalPath, fset.Position(recFunc.Func.Pos()).Filename)
+		line := fset.Position(recFunc.Func.Pos()).Line
+		refs = append(refs, models.ReconcilerRef{
+			ID:           reconcilerID(repo, relPath, line),
+			Repo:         repoName(repo),
+			File:         relPath,
+			Line:         line,
+			ReceiverType: recFunc.ReceiverType,
+			ReceiverPkg:  recFunc.ReceiverPkg,
+		})
+	}
+	return refs, nil
+}
+
+// discover loads a repository and finds its Reconcile functions, with the
+// receiver and changed file filters applied.
+func (a *Analyzer) discover(repo models.Repository) ([]ReconcileFunc, *token.FileSet, models.RepoInventory, error) {
+	inv := models.RepoInventory{Repo: repo.URL}
+
+	pkgs, failed, quality, err := a.loadPackages(a.moduleDir(repo))
+	if err != nil {
+		return nil, nil, inv, fmt.Errorf("failed to load packages: %w", err)
+	}
+	inv.Packages = len(pkgs) + failed
+	inv.PackageErrors = failed
+	inv.LoadQuality = quality
+	a.addModuleInfo(&inv, a.moduleDir(repo))
+
+	var fset *token.FileSet
+	if len(pkgs) > 0 && pkgs[0].Fset != nil {
+		fset = pkgs[0].Fset
+	} else {
+		fset = token.NewFileSet()
+	}
+
+	finder := NewReconcileFinder(fset)
+	finder.IncludeTests = a.IncludeTests
+	finder.StrictSignature = a.StrictSignature
+	funcs, err := findReconcileFuncs(finder, pkgs)
+	if err != nil {
+		return nil, nil, inv, err
+	}
+	funcs = a.filterChangedFiles(a.filterReceivers(funcs), repo.LocalPath, fset)
+
+	return funcs, fset, inv, nil
+}
+
+// findReconcileFuncs runs discovery over pkgs. Packages that failed to load
+// may leave partial syntax behind; a panic on it is reported as an error.
+func findReconcileFuncs(finder *ReconcileFinder, pkgs []*packages.Package) (funcs []ReconcileFunc, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic discovering Reconcile functions: %v", p)
+		}
+	}()
+	return finder.FindReconcileFunctions(pkgs), nil
+}
+
+// loadMode is the packages.Load mode for fully type-checked loading.
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports
+
+// loadPackages loads all Go packages from a repository. It returns the
+// usable packages, the number of packages that failed to load, and the
+// models.LoadQuality* value describing how much type information survived.
+//
+// If type checking fails, module dependencies are downloaded and loading is
+// retried once against a scratch copy of go.mod and go.sum, so the
+// repository's own module files are never rewritten. Packages that still
+// fail keep whatever syntax and type information type checking produced.
+func (a *Analyzer) loadPackages(repoPath string) ([]*packages.Package, int, string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := a.load(repoPath, fset, loadMode, "GOFLAGS=-tags=")
+	if err != nil {
+		return nil, 0, models.LoadQualityFailed, err
+	}
+
+	retryFlags := "GOFLAGS=-tags="
+	if hasLoadErrors(pkgs) {
+		modfile, cleanup, err := a.scratchModfile(repoPath)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		defer cleanup()
+		if modfile != "" {
+			retryFlags = "GOFLAGS=-tags= -mod=mod -modfile=" + modfile
+		}
+
+		if a.verbose {
+			log.Printf("Type checking failed in %s, downloading modules and retrying", repoPath)
+		}
+		if err := downloadModules(repoPath, retryFlags); err != nil && a.verbose {
+			log.Printf("Error downloading modules in %s: %v", repoPath, err)
+		}
+		retried, err := a.load(repoPath, fset, loadMode, retryFlags)
+		if err == nil {
+			pkgs = retried
+		} else if a.verbose {
+			log.Printf("Error reloading packages in %s: %v", repoPath, err)
+		}
+	}
+
+	// Packages that type checking left without syntax are parsed again
+	// without types.
+	var syntaxOnly map[string]*packages.Package
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 && len(pkg.Syntax) == 0 {
+			syntaxOnly = a.loadSyntaxOnly(repoPath, fset, retryFlags)
+			break
+		}
+	}
+
+	// Paths the repository asks to leave out.
+	ignore, err := a.loadIgnoreRules(repoPath)
+	if err != nil {
+		log.Printf("Error reading ignore file in %s: %v", repoPath, err)
+	}
+
 	// Filter out packages with errors (but still return what we can).
 	var validPkgs []*packages.Package
+	failed, degraded, partial := 0, 0, 0
 	for _, pkg := range pkgs {
-		if len(pkg.Errors) > 0 && len(pkg.Syntax) == 0 {
+		if a.SkipPackage != nil && a.SkipPackage.MatchString(pkg.PkgPath) {
 			if a.verbose {
-				for _, e := range pkg.Errors {
-					log.Printf("Package error in %s: %v", pkg.PkgPath, e)
-				}
+				log.Printf("Skipping package %s", pkg.PkgPath)
 			}
-		} else {
+			continue
+		}
+		if len(ignore) > 0 && ignore.Match(packageDir(repoPath, pkg)) {
+			if a.verbose {
+				log.Printf("Skipping package %s (%s)", pkg.PkgPath, IgnoreFile)
+			}
+			continue
+		}
+		if len(pkg.Errors) == 0 {
 			validPkgs = append(validPkgs, pkg)
+			continue
+		}
+
+		if a.verbose {
+			for _, e := range pkg.Errors {
+				log.Printf("Package error in %s: %v", pkg.PkgPath, e)
+			}
+		}
+		if len(pkg.Syntax) == 0 {
+			if fallback, ok := syntaxOnly[pkg.ID]; ok && len(fallback.Syntax) > 0 {
+				pkg = fallback
+			} else {
+				failed++
+				continue
+			}
+		}
+		// Type checking records what it could resolve even when it fails,
+		// so detectors keep the types they find and fall back to names
+		// for the rest.
+		if pkg.TypesInfo == nil {
+			degraded++
+		} else {
+			partial++
+		}
+		validPkgs = append(validPkgs, pkg)
+	}
+
+	quality := models.LoadQualityFull
+	switch {
+	case len(validPkgs) == 0 && failed > 0:
+		quality = models.LoadQualityFailed
+	case degraded > 0:
+		quality = models.LoadQualitySyntaxOnly
+	case partial > 0:
+		quality = models.LoadQualityPartial
+	}
+
+	return validPkgs, failed, quality, nil
+}
+
+// load runs packages.Load over the whole repository.
+func (a *Analyzer) load(repoPath string, fset *token.FileSet, mode packages.LoadMode, goflags string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode:    mode,
+		Dir:     repoPath,
+		Env:     append(os.Environ(), goflags),
+		Fset:    fset,
+		Tests:   a.IncludeTests,
+		Overlay: a.overlay,
+	}
+	return packages.Load(cfg, "./...")
+}
+
+// loadSyntaxOnly parses the repository's packages without type checking,
+// keyed by package ID.
+func (a *Analyzer) loadSyntaxOnly(repoPath string, fset *token.FileSet, goflags string) map[string]*packages.Package {
+	pkgs, err := a.load(repoPath, fset, packages.NeedName|packages.NeedFiles|packages.NeedSyntax, goflags)
+	if err != nil {
+		if a.verbose {
+			log.Printf("Error parsing packages in %s: %v", repoPath, err)
+		}
+		return nil
+	}
+
+	byID := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		byID[pkg.ID] = pkg
+	}
+	return byID
+}
+
+// hasLoadErrors reports whether any loaded package has errors.
+func hasLoadErrors(pkgs []*packages.Package) bool {
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// scratchModfile copies the go.mod and go.sum of repoPath, from the overlay
+// or disk, into a temporary directory and returns the copied go.mod, for use
+// with -modfile, and a function removing the copy. It returns "" if repoPath
+// has no go.mod.
+func (a *Analyzer) scratchModfile(repoPath string) (string, func(), error) {
+	noop := func() {}
+	data, err := a.readFile(filepath.Join(repoPath, "go.mod"))
+	if os.IsNotExist(err) {
+		return "", noop, nil
+	}
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "survey-modfile-")
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to create scratch modfile: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	modfile := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(modfile, data, 0644); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to create scratch modfile: %w", err)
+	}
+	// go.sum is looked up next to the modfile.
+	if sum, err := a.readFile(filepath.Join(repoPath, "go.sum")); err == nil {
+		if err := os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0644); err != nil {
+			cleanup()
+			return "", noop, fmt.Errorf("failed to create scratch modfile: %w", err)
 		}
 	}
+	return modfile, cleanup, nil
+}
 
-	return validPkgs, nil
+// downloadModules runs go mod download in the repository with goflags.
+func downloadModules(repoPath, goflags string) error {
+	cmd := exec.Command("go", "mod", "download")
+	cmd.Dir = repoPath
+	cmd.Env = append(os.Environ(), goflags)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go mod download failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// readFile reads a source file, preferring its overlay contents.
+func (a *Analyzer) readFile(path string) ([]byte, error) {
+	if data, ok := a.overlay[path]; ok {
+		return data, nil
+	}
+	return os.ReadFile(path)
 }
 
-// analyzeReconcileFunc analyzes a single Reconcile function.
+// analyzeReconcileFunc analyzes a single Reconcile function. Packages that
+// failed to load may leave partial syntax behind; a detector panicking on it
+// is reported as an error for this function only.
 func (a *Analyzer) analyzeReconcileFunc(
 	recFunc ReconcileFunc,
 	repo models.Repository,
 	fset *token.FileSet,
-) (models.Reconciler, error) {
+) (reconciler models.Reconciler, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic analyzing %s at %s: %v", recFunc.ReceiverType, fset.Position(recFunc.Func.Pos()), p)
+		}
+	}()
+
 	// Get file path and position.
 	filePath := fset.Position(recFunc.Func.Pos()).Filename
 	line := fset.Position(recFunc.Func.Pos()).Line
 	endLine := fset.Position(recFunc.Func.End()).Line
 
 	// Make file path relative to repo.
-	relPath, err := filepath.Rel(repo.LocalPath, filePath)
-	if err != nil {
-		relPath = filePath
-	}
+	relPath := repoRelPath(repo.LocalPath, filePath)
 
 	// Read file data for snippet extraction.
-	fileData, err := os.ReadFile(filePath)
+	fileData, err := a.readFile(filePath)
 	if err != nil {
 		if a.verbose {
 			log.Printf("Warning: could not read file %s: %v", filePath, err)
@@ -135,23 +596,62 @@ func (a *Analyzer) analyzeReconcileFunc(
 	// Extract request parameter name.
 	reqParamName := ExtractReqParamName(recFunc.Func)
 
-	// Create pattern detector.
-	detector := NewPatternDetector(fset, recFunc.Pkg, fileData, reqParamName)
+	// Create pattern detector, teaching it the receiver's client fields.
+	detector := a.newDetector(fset, recFunc.Pkg, fileData, reqParamName)
+	if recv := receiverStruct(recFunc.Func, recFunc.Pkg); recv != nil {
+		detector.clientFieldNames = append(detector.clientFieldNames, ExtractClientFieldName(recv)...)
+	}
+	// Lists by a field index are judged against the indexes setup registers.
+	if recFunc.Setup != nil {
+		detector.indexedFields = detector.fieldIndexes(recFunc.Setup)
+	}
 
 	// Detect patterns.
-	signals := detector.DetectPatterns(recFunc.Func)
+	signals := sortSignals(detector.DetectPatterns(recFunc.Func))
+
+	// Detect setup patterns, which may live in a different file.
+	var primaryType string
+	var watchedTypes []string
+	var controllerOptions *models.ControllerOptions
+	var predicates []string
+	if recFunc.Setup != nil {
+		setupData := fileData
+		setupPath := fset.Position(recFunc.Setup.Pos()).Filename
+		if setupPath != filePath {
+			setupData, _ = a.readFile(setupPath)
+		}
+		setupDetector := a.newDetector(fset, recFunc.Pkg, setupData, reqParamName)
+		setupSignals := sortSignals(setupDetector.DetectSetupPatterns(recFunc.Setup))
+		if setupPath != filePath {
+			setupRel := repoRelPath(repo.LocalPath, setupPath)
+			for i := range setupSignals {
+				setupSignals[i].File = setupRel
+			}
+		}
+		signals = append(signals, setupSignals...)
+		primaryType = setupDetector.PrimaryType()
+		watchedTypes = setupDetector.WatchedTypes()
+		controllerOptions = setupDetector.ControllerOptions()
+		predicates = setupDetector.Predicates()
+		for _, w := range setupDetector.Warnings() {
+			detector.warnf("setup: %s", w)
+		}
+	}
+	if primaryType == "" {
+		primaryType = recFunc.ObjectType
+	}
 
 	// Classify.
 	score, classification := Classify(signals)
-
-	// Build reconciler ID.
-	repoName := strings.TrimPrefix(repo.URL, "https://github.com/")
-	repoName = strings.TrimPrefix(repoName, "http://github.com/")
-	id := fmt.Sprintf("%s#%s#%d", repoName, relPath, line)
+	var trace *models.DecisionTrace
+	if a.Explain {
+		t := models.DefaultProfile().Explain(signals)
+		trace = &t
+	}
 
 	return models.Reconciler{
-		ID:             id,
-		Repo:           repoName,
+		ID:             reconcilerID(repo, relPath, line),
+		Repo:           repoName(repo),
 		File:           relPath,
 		Line:           line,
 		EndLine:        endLine,
@@ -160,15 +660,61 @@ func (a *Analyzer) analyzeReconcileFunc(
 		Score:          score,
 		Classification: classification,
 		Signals:        signals,
+		PrimaryType:    primaryType,
+		WatchedTypes:   watchedTypes,
+		IsGeneric:      detector.IsGeneric(),
+		RequeuesAlways: detector.RequeuesAlways(),
+		NeverRequeues:  detector.NeverRequeues(),
+		Direction:      detector.Direction(),
+
+		IsWebhookAttached: recFunc.WebhookAttached,
+		IsFunctional:      recFunc.Functional,
+		ReadOnly:          detector.ReadOnly(),
+		HasConditions:     detector.HasConditions(),
+		ManagesChildren:   detector.ManagesChildren(),
+		RespectsPause:     detector.RespectsPause(),
+		DynamicWatches:    detector.DynamicWatches(),
+		ReadKinds:         detector.ReadKinds(),
+		HasHashGate:       detector.HasHashGate(),
+		ExternalEffects:   detector.ExternalEffects(),
+		ControllerOptions: controllerOptions,
+		Predicates:        predicates,
+		Warnings:          detector.Warnings(),
+		Trace:             trace,
 	}, nil
 }
 
+// sortSignals orders signals by line, then type. Setup signals are sorted
+// separately since they may come from another file.
+func sortSignals(signals []models.Signal) []models.Signal {
+	sort.SliceStable(signals, func(i, j int) bool {
+		if signals[i].Line != signals[j].Line {
+			return signals[i].Line < signals[j].Line
+		}
+		return signals[i].Type < signals[j].Type
+	})
+	return signals
+}
+
+// newDetector creates a PatternDetector configured from the analyzer options.
+func (a *Analyzer) newDetector(fset *token.FileSet, pkg *packages.Package, fileData []byte, reqParamName string) *PatternDetector {
+	detector := NewPatternDetector(fset, pkg, fileData, reqParamName)
+	detector.SnippetLength = a.SnippetLength
+	detector.FormatSnippets = a.FormatSnippets
+	if a.NeutralKinds != nil {
+		detector.NeutralKinds = a.NeutralKinds
+	}
+	detector.Detectors = append(detector.Detectors, a.Detectors...)
+	return detector
+}
+
 // CloneRepo clones a repository to the work directory.
 func (a *Analyzer) CloneRepo(repoURL string) (string, error) {
 	// Extract repo name from URL.
-	parts := strings.Split(strings.TrimSuffix(repoURL, ".git"), "/")
-	repoName := parts[len(parts)-1]
-	ownerName := parts[len(parts)-2]
+	ownerName, repoName := ParseRepoURL(repoURL)
+	if ownerName == "" || repoName == "" {
+		return "", fmt.Errorf("invalid repo URL: %s", repoURL)
+	}
 
 	localPath := filepath.Join(a.workDir, ownerName, repoName)
 
@@ -194,12 +740,68 @@ func (a *Analyzer) CloneRepo(repoURL string) (string, error) {
 	return localPath, nil
 }
 
-// ParseRepoURL extracts owner and name from a GitHub URL.
+// NormalizeRepoURL expands shorthand repository references to full GitHub URLs.
+// Accepted forms: "owner/repo", "github.com/owner/repo", and any full URL,
+// which is returned unchanged.
+func NormalizeRepoURL(url string) string {
+	url = strings.TrimSpace(url)
+	if strings.Contains(url, "://") || strings.HasPrefix(url, "git@") {
+		return url
+	}
+
+	url = strings.TrimPrefix(url, "github.com/")
+	if strings.Count(strings.TrimSuffix(url, "/"), "/") == 1 {
+		return "https://github.com/" + url
+	}
+
+	return url
+}
+
+// repoName returns the owner/name a repository's reconcilers are reported
+// under, falling back to its URL.
+func repoName(repo models.Repository) string {
+	if repo.Owner != "" && repo.Name != "" {
+		return repo.Owner + "/" + repo.Name
+	}
+	if owner, name := ParseRepoURL(repo.URL); owner != "" && name != "" {
+		return owner + "/" + name
+	}
+	name := strings.TrimPrefix(repo.URL, "https://github.com/")
+	return strings.TrimPrefix(name, "http://github.com/")
+}
+
+// reconcilerID builds the unique repo#file#line ID of a reconciler.
+func reconcilerID(repo models.Repository, relPath string, line int) string {
+	return fmt.Sprintf("%s#%s#%d", repoName(repo), relPath, line)
+}
+
+// ParseRepoURL extracts owner and name from a repository URL.
+// Supported forms include https://github.com/owner/repo, owner/repo,
+// scp-style git@github.com:owner/repo.git and ssh://git@github.com/owner/repo.git.
 func ParseRepoURL(url string) (owner, name string) {
-	url = strings.TrimPrefix(url, "https://github.com/")
-	url = strings.TrimPrefix(url, "http://github.com/")
-	url = strings.TrimSuffix(url, ".git")
+	url = strings.TrimSpace(url)
 	url = strings.TrimSuffix(url, "/")
+	url = strings.TrimSuffix(url, ".git")
+
+	switch {
+	case strings.Contains(url, "://"):
+		// Drop the scheme and the host (which may carry a user or port).
+		url = url[strings.Index(url, "://")+3:]
+		idx := strings.Index(url, "/")
+		if idx < 0 {
+			return "", ""
+		}
+		url = url[idx+1:]
+	case strings.HasPrefix(url, "git@"):
+		// scp-style: git@host:owner/repo.
+		idx := strings.Index(url, ":")
+		if idx < 0 {
+			return "", ""
+		}
+		url = url[idx+1:]
+	default:
+		url = strings.TrimPrefix(url, "github.com/")
+	}
 
 	parts := strings.Split(url, "/")
 	if len(parts) >= 2 {
@@ -0,0 +1,363 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestNormalizeRepoURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"cert-manager/cert-manager", "https://github.com/cert-manager/cert-manager"},
+		{" cert-manager/cert-manager\n", "https://github.com/cert-manager/cert-manager"},
+		{"github.com/fluxcd/flux2", "https://github.com/fluxcd/flux2"},
+		{"https://github.com/fluxcd/flux2", "https://github.com/fluxcd/flux2"},
+		{"https://gitlab.com/group/sub/project", "https://gitlab.com/group/sub/project"},
+		{"git@github.com:fluxcd/flux2.git", "git@github.com:fluxcd/flux2.git"},
+		{"not-a-repo", "not-a-repo"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeRepoURL(tt.in); got != tt.want {
+			t.Errorf("NormalizeRepoURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	tests := []struct {
+		url         string
+		owner, name string
+	}{
+		{"https://github.com/fluxcd/flux2", "fluxcd", "flux2"},
+		{"https://github.com/fluxcd/flux2.git", "fluxcd", "flux2"},
+		{"https://github.com/fluxcd/flux2/", "fluxcd", "flux2"},
+		{"fluxcd/flux2", "fluxcd", "flux2"},
+		{"github.com/fluxcd/flux2", "fluxcd", "flux2"},
+		{"git@github.com:fluxcd/flux2.git", "fluxcd", "flux2"},
+		{"ssh://git@github.com/fluxcd/flux2.git", "fluxcd", "flux2"},
+		{"ssh://git@github.com:22/fluxcd/flux2.git", "fluxcd", "flux2"},
+		{"https://github.com", "", ""},
+		{"git@github.com", "", ""},
+		{"flux2", "", ""},
+	}
+	for _, tt := range tests {
+		owner, name := ParseRepoURL(tt.url)
+		if owner != tt.owner || name != tt.name {
+			t.Errorf("ParseRepoURL(%q) = %q, %q, want %q, %q", tt.url, owner, name, tt.owner, tt.name)
+		}
+	}
+}
+
+func TestFilterReceivers(t *testing.T) {
+	funcs := []ReconcileFunc{{ReceiverType: "WidgetReconciler"}, {ReceiverType: "GadgetReconciler"}, {ReceiverType: "WidgetStatusReconciler"}}
+	tests := []struct {
+		name             string
+		include, exclude string
+		want             []string
+	}{
+		{name: "no filters", want: []string{"WidgetReconciler", "GadgetReconciler", "WidgetStatusReconciler"}},
+		{name: "include", include: "^Widget", want: []string{"WidgetReconciler", "WidgetStatusReconciler"}},
+		{name: "exclude", exclude: "Status", want: []string{"WidgetReconciler", "GadgetReconciler"}},
+		{name: "both", include: "^Widget", exclude: "Status", want: []string{"WidgetReconciler"}},
+		{name: "nothing left", include: "^Nope", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyzer(t.TempDir(), false)
+			if tt.include != "" {
+				a.IncludeReceiver = regexp.MustCompile(tt.include)
+			}
+			if tt.exclude != "" {
+				a.ExcludeReceiver = regexp.MustCompile(tt.exclude)
+			}
+			var got []string
+			for _, f := range a.filterReceivers(funcs) {
+				got = append(got, f.ReceiverType)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterReceivers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// writeRepo writes files into a new repository directory. Its sources keep
+// to local types: standard library packages fail to load with type
+// information in some toolchain combinations.
+func writeRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// reconcilerSource is a package with one Reconcile method on recv.
+func reconcilerSource(pkg, recv string) string {
+	return "package " + pkg + `
+
+type Context interface{}
+type Request struct{ Name string }
+type Result struct{}
+
+type ` + recv + ` struct{}
+
+func (r *` + recv + `) Reconcile(ctx Context, req Request) (Result, error) {
+	return Result{}, nil
+}
+`
+}
+
+func TestInventory(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"go.mod":             "module example.com/inventory\n\ngo 1.21\n",
+		"controllers/a/a.go": reconcilerSource("a", "WidgetReconciler"),
+		"controllers/b/b.go": reconcilerSource("b", "GadgetReconciler"),
+		"internal/util/u.go": "package util\n",
+	})
+	a := NewAnalyzer(t.TempDir(), false)
+	inv, err := a.Inventory(models.Repository{URL: dir, LocalPath: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.Packages != 3 || inv.PackageErrors != 0 || inv.Reconcilers != 2 || inv.LoadQuality != models.LoadQualityFull {
+		t.Errorf("Inventory() = %+v, want 3 packages, 2 reconcilers, full type information", inv)
+	}
+}
+
+func TestSkipPackage(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"go.mod":                   "module example.com/skip\n\ngo 1.21\n",
+		"controllers/a/a.go":       reconcilerSource("a", "WidgetReconciler"),
+		"vendored/operator/b/b.go": reconcilerSource("b", "GadgetReconciler"),
+	})
+	tests := []struct {
+		skip string
+		want []string
+	}{
+		{"", []string{"GadgetReconciler", "WidgetReconciler"}},
+		{"/vendored/", []string{"WidgetReconciler"}},
+		{"^example.com/skip/", nil},
+	}
+	for _, tt := range tests {
+		a := NewAnalyzer(t.TempDir(), false)
+		if tt.skip != "" {
+			a.SkipPackage = regexp.MustCompile(tt.skip)
+		}
+		refs, err := a.ListReconcilers(models.Repository{URL: dir, LocalPath: dir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, ref := range refs {
+			got = append(got, ref.ReceiverType)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SkipPackage %q: found %v, want %v", tt.skip, got, tt.want)
+		}
+	}
+}
+
+func TestLoadPartialTypes(t *testing.T) {
+	const gomod = "module example.com/broken\n\ngo 1.21\n"
+	dir := writeRepo(t, map[string]string{
+		"go.mod":             gomod,
+		"controllers/a/a.go": reconcilerSource("a", "WidgetReconciler"),
+		// A type error leaves the package with partial type information.
+		"controllers/b/b.go": reconcilerSource("b", "GadgetReconciler") + "\nvar _ = undefinedHelper()\n",
+	})
+	a := NewAnalyzer(t.TempDir(), false)
+	inv, err := a.Inventory(models.Repository{URL: dir, LocalPath: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.Reconcilers != 2 || inv.PackageErrors != 0 || inv.LoadQuality != models.LoadQualityPartial {
+		t.Errorf("Inventory() = %+v, want both reconcilers kept with partial type information", inv)
+	}
+
+	// The retry must not touch the analyzed checkout.
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil || string(data) != gomod {
+		t.Errorf("go.mod after loading = %q, %v, want it unchanged", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.sum")); !os.IsNotExist(err) {
+		t.Errorf("go.sum created in the checkout (%v)", err)
+	}
+}
+
+func TestSortSignals(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []models.Signal
+		want []models.Signal
+	}{
+		{
+			name: "by line",
+			in:   []models.Signal{{Type: "b", Line: 20}, {Type: "a", Line: 10}},
+			want: []models.Signal{{Type: "a", Line: 10}, {Type: "b", Line: 20}},
+		},
+		{
+			name: "same line by type",
+			in:   []models.Signal{{Type: "status_update", Line: 10}, {Type: "client_list", Line: 10}},
+			want: []models.Signal{{Type: "client_list", Line: 10}, {Type: "status_update", Line: 10}},
+		},
+		{
+			name: "equal keys keep their order",
+			in:   []models.Signal{{Type: "a", Line: 10, Snippet: "first"}, {Type: "a", Line: 10, Snippet: "second"}},
+			want: []models.Signal{{Type: "a", Line: 10, Snippet: "first"}, {Type: "a", Line: 10, Snippet: "second"}},
+		},
+		{name: "none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortSignals(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortSignals() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcilerID(t *testing.T) {
+	tests := []struct {
+		repo models.Repository
+		want string
+	}{
+		{models.Repository{Owner: "acme", Name: "widgets", URL: "https://gitlab.com/x/y"}, "acme/widgets#controllers/foo.go#12"},
+		{models.Repository{URL: "https://gitlab.com/acme/widgets"}, "acme/widgets#controllers/foo.go#12"},
+		{models.Repository{URL: "https://github.com/widgets"}, "widgets#controllers/foo.go#12"},
+	}
+	for _, tt := range tests {
+		if got := reconcilerID(tt.repo, "controllers/foo.go", 12); got != tt.want {
+			t.Errorf("reconcilerID(%+v) = %q, want %q", tt.repo, got, tt.want)
+		}
+	}
+}
+
+func TestListReconcilers(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"go.mod":             "module example.com/list\n\ngo 1.21\n",
+		"controllers/a/a.go": reconcilerSource("a", "WidgetReconciler"),
+		"controllers/b/b.go": reconcilerSource("b", "GadgetReconciler"),
+	})
+	repo := models.Repository{URL: "https://github.com/acme/list", Owner: "acme", Name: "list", LocalPath: dir}
+	a := NewAnalyzer(t.TempDir(), false)
+	refs, err := a.ListReconcilers(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reconcilers, err := a.AnalyzeRepo(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Discovery alone locates the same reconcilers a full analysis reports.
+	got := refs
+	var want []models.ReconcilerRef
+	for _, r := range reconcilers {
+		want = append(want, models.ReconcilerRef{ID: r.ID, Repo: r.Repo, File: r.File, Line: r.Line, ReceiverType: r.ReceiverType, ReceiverPkg: r.ReceiverPkg})
+	}
+	byID := func(refs []models.ReconcilerRef) {
+		sort.Slice(refs, func(i, j int) bool { return refs[i].ID < refs[j].ID })
+	}
+	byID(got)
+	byID(want)
+	if len(got) != 2 || !reflect.DeepEqual(got, want) {
+		t.Errorf("ListReconcilers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFuncWorkers(t *testing.T) {
+	tests := []struct {
+		workers, n, want int
+	}{
+		{workers: 4, n: 10, want: 4},
+		{workers: 4, n: 2, want: 2},
+		{workers: 0, n: 1, want: 1},
+		{workers: -1, n: 1000, want: runtime.GOMAXPROCS(0)},
+		{workers: 4, n: 0, want: 0},
+	}
+	for _, tt := range tests {
+		a := &Analyzer{FuncWorkers: tt.workers}
+		if got := a.funcWorkers(tt.n); got != tt.want {
+			t.Errorf("FuncWorkers=%d: funcWorkers(%d) = %d, want %d", tt.workers, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParallelAnalysisOrder(t *testing.T) {
+	files := map[string]string{"go.mod": "module example.com/parallel\n\ngo 1.21\n"}
+	for i := 0; i < 12; i++ {
+		pkg := fmt.Sprintf("c%02d", i)
+		files["controllers/"+pkg+"/"+pkg+".go"] = reconcilerSource(pkg, fmt.Sprintf("Reconciler%02d", i))
+	}
+	dir := writeRepo(t, files)
+	repo := models.Repository{URL: dir, LocalPath: dir}
+
+	ids := func(workers int) []string {
+		a := NewAnalyzer(t.TempDir(), false)
+		a.FuncWorkers = workers
+		reconcilers, err := a.AnalyzeRepo(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, r := range reconcilers {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+	serial := ids(1)
+	if len(serial) != 12 {
+		t.Fatalf("found %d reconcilers, want 12", len(serial))
+	}
+	for _, workers := range []int{2, 8} {
+		if got := ids(workers); !reflect.DeepEqual(got, serial) {
+			t.Errorf("FuncWorkers=%d: order %v, want discovery order %v", workers, got, serial)
+		}
+	}
+}
+
+func TestSubdir(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"go.mod":                      "module example.com/platform\n\ngo 1.21\n",
+		"controllers/a/a.go":          reconcilerSource("a", "PlatformReconciler"),
+		"operator/go.mod":             "module example.com/platform/operator\n\ngo 1.21\n",
+		"operator/controllers/b/b.go": reconcilerSource("b", "OperatorReconciler"),
+	})
+	tests := []struct {
+		subdir     string
+		file       string
+		modulePath string
+	}{
+		{"", "controllers/a/a.go", "example.com/platform"},
+		{"operator", "operator/controllers/b/b.go", "example.com/platform/operator"},
+	}
+	for _, tt := range tests {
+		a := NewAnalyzer(t.TempDir(), false)
+		a.Subdir = tt.subdir
+		reconcilers, inv, err := a.AnalyzeRepoWithInventory(models.Repository{URL: dir, LocalPath: dir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reconcilers) != 1 || reconcilers[0].File != tt.file || inv.ModulePath != tt.modulePath {
+			t.Errorf("Subdir %q: found %+v in module %q, want one reconciler in %s of %s", tt.subdir, reconcilers, inv.ModulePath, tt.file, tt.modulePath)
+		}
+	}
+}
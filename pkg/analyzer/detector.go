@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"sync"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+	"golang.org/x/tools/go/packages"
+)
+
+// Detector is a pluggable signal detector run over every Reconcile function.
+// The built-in detectors implement it too and run first. Custom detectors set
+// the Score of the signals they return themselves; their types need not be in
+// the catalog.
+type Detector interface {
+	// Name identifies the detector, e.g. in logs.
+	Name() string
+
+	// Detect returns the signals found in fn.
+	Detect(fn *ast.FuncDecl, ctx DetectContext) []models.Signal
+}
+
+// DetectContext describes the function a Detector is run on.
+type DetectContext struct {
+	Fset *token.FileSet
+	Pkg  *packages.Package
+
+	// ReqParamName is the name of the Reconcile request parameter.
+	ReqParamName string
+
+	// Signals are the signals detected so far, in detection order.
+	Signals []models.Signal
+
+	pd *PatternDetector
+}
+
+// Snippet extracts the source of node, honoring the analyzer's snippet
+// length and formatting options.
+func (c DetectContext) Snippet(node ast.Node) string {
+	return c.pd.extractSnippet(node)
+}
+
+// IsClientCall reports whether sel looks like a controller-runtime client
+// method call, using the receiver's discovered client fields.
+func (c DetectContext) IsClientCall(sel *ast.SelectorExpr) bool {
+	return c.pd.isClientCall(sel)
+}
+
+// DerivesFromReq reports whether expr references the request parameter or
+// a local variable derived from it.
+func (c DetectContext) DerivesFromReq(expr ast.Expr) bool {
+	return c.pd.derivesFromReq(expr)
+}
+
+// detectorFunc adapts a function to the Detector interface.
+type detectorFunc struct {
+	name   string
+	detect func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal
+}
+
+// NewDetector creates a Detector from a function.
+func NewDetector(name string, detect func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal) Detector {
+	return detectorFunc{name: name, detect: detect}
+}
+
+// Name implements Detector.
+func (d detectorFunc) Name() string { return d.name }
+
+// Detect implements Detector.
+func (d detectorFunc) Detect(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+	return d.detect(fn, ctx)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []Detector
+)
+
+// RegisterDetector adds a detector run by every PatternDetector created
+// afterwards. Call it before analysis starts, e.g. from an init function.
+func RegisterDetector(d Detector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, d)
+}
+
+// RegisteredDetectors returns the registered detectors, built-in ones first.
+func RegisteredDetectors() []Detector {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]Detector(nil), registry...)
+}
+
+// Built-in detectors: the body walk, then the composite detectors, which
+// combine the signals of the walk.
+func init() {
+	RegisterDetector(NewDetector("body_walk", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		return ctx.pd.walkBody(fn.Body)
+	}))
+	RegisterDetector(NewDetector("diff_sync", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		return single(ctx.pd.detectDiffSync(fn.Body, ctx.Signals))
+	}))
+	RegisterDetector(NewDetector("requeue_immediate", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		return single(ctx.pd.detectImmediateRequeue(fn.Body))
+	}))
+	RegisterDetector(NewDetector("get_mutate_update", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		return single(ctx.pd.detectGetMutateUpdate(fn.Body))
+	}))
+	RegisterDetector(NewDetector("time_driven", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		return single(ctx.pd.detectTimeDriven(fn.Body))
+	}))
+	RegisterDetector(NewDetector("hash_gate", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		return single(ctx.pd.detectHashGate(fn.Body))
+	}))
+	RegisterDetector(NewDetector("broad_read_surface", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		return single(ctx.pd.detectBroadReads(fn, ctx.Signals))
+	}))
+}
+
+// single returns sig as a slice, or nil if it is empty.
+func single(sig models.Signal) []models.Signal {
+	if sig.Type == "" {
+		return nil
+	}
+	return []models.Signal{sig}
+}
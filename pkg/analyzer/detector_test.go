@@ -0,0 +1,61 @@
+package analyzer
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestCustomDetector(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "fixtures", "list_unscoped.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Records the signals seen so far, which the built-ins found first.
+	var seen int
+	a := NewAnalyzer(t.TempDir(), false)
+	a.Detectors = []Detector{NewDetector("returns", func(fn *ast.FuncDecl, ctx DetectContext) []models.Signal {
+		seen = len(ctx.Signals)
+		return []models.Signal{{Type: "custom_returns", Line: ctx.Fset.Position(fn.Pos()).Line, Score: 7}}
+	})}
+
+	reconcilers, err := a.AnalyzeSource(map[string]string{"fixture.go": string(src)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reconcilers) != 1 {
+		t.Fatalf("got %d reconcilers, want 1", len(reconcilers))
+	}
+	var custom *models.Signal
+	for i, sig := range reconcilers[0].Signals {
+		if sig.Type == "custom_returns" {
+			custom = &reconcilers[0].Signals[i]
+		}
+	}
+	if custom == nil || custom.Score != 7 {
+		t.Fatalf("signals = %+v, want the custom one with score 7", reconcilers[0].Signals)
+	}
+	if seen == 0 {
+		t.Error("custom detector saw no signals, want the built-in ones")
+	}
+}
+
+func TestBuiltinDetectorsRegistered(t *testing.T) {
+	detectors := RegisteredDetectors()
+	if len(detectors) == 0 || detectors[0].Name() != "body_walk" {
+		t.Fatalf("first registered detector is not the body walk: %v", detectors)
+	}
+	names := make(map[string]bool)
+	for _, d := range detectors {
+		names[d.Name()] = true
+	}
+	for _, name := range []string{"diff_sync", "requeue_immediate", "get_mutate_update", "time_driven", "hash_gate", "broad_read_surface"} {
+		if !names[name] {
+			t.Errorf("built-in detector %s is not registered", name)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// noImporter fails every import, leaving type information partial as it is
+// for repositories whose dependencies cannot be resolved.
+type noImporter struct{}
+
+func (noImporter) Import(path string) (*types.Package, error) {
+	return nil, fmt.Errorf("import %q not available", path)
+}
+
+// FuzzDetectPatterns runs discovery and every detector over arbitrary,
+// possibly malformed, source. Seeded with the golden fixtures; none of it
+// may panic, with or without (partial) type information.
+func FuzzDetectPatterns(f *testing.F) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*.go"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, fixture := range fixtures {
+		src, err := os.ReadFile(fixture)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(src, true)
+	}
+	// Client calls with too few arguments, as left by partial syntax.
+	f.Add([]byte(`package fixture
+
+type R struct{ Client interface{} }
+
+func (r *R) Reconcile(ctx context.Context, req Request) (Result, error) {
+	r.Client.List()
+	r.Client.List(ctx)
+	r.Client.Get(ctx, req.NamespacedName)
+	r.Client.DeleteAllOf(ctx)
+	r.Client.Patch(ctx, obj)
+	r.Client.Status().Update()
+	controllerutil.CreateOrUpdate(ctx, r.Client, obj)
+	for _, item := range list.Items {
+		r.Client.Update()
+	}
+	return Result{}, nil
+}
+
+func (r *R) SetupWithManager(mgr Manager) error {
+	mgr.GetFieldIndexer().IndexField()
+	return NewControllerManagedBy(mgr).For().Owns().Watches().WithOptions().Complete(r)
+}
+`), false)
+
+	f.Fuzz(func(t *testing.T, src []byte, typed bool) {
+		fset := token.NewFileSet()
+		file, parseErr := parser.ParseFile(fset, "fuzz.go", src, parser.ParseComments)
+		if file == nil {
+			return
+		}
+
+		// Syntax errors are only analyzed untyped: go/types itself can
+		// overflow the stack on the cyclic garbage they produce.
+		pkg := &packages.Package{ID: "fixture", Name: "fixture", PkgPath: "fixture", Fset: fset, Syntax: []*ast.File{file}}
+		if typed && parseErr == nil {
+			info := &types.Info{
+				Types:      make(map[ast.Expr]types.TypeAndValue),
+				Defs:       make(map[*ast.Ident]types.Object),
+				Uses:       make(map[*ast.Ident]types.Object),
+				Implicits:  make(map[ast.Node]types.Object),
+				Selections: make(map[*ast.SelectorExpr]*types.Selection),
+				Scopes:     make(map[ast.Node]*types.Scope),
+			}
+			conf := types.Config{Importer: noImporter{}, Error: func(error) {}}
+			pkg.Types, _ = conf.Check("fixture", fset, []*ast.File{file}, info)
+			pkg.TypesInfo = info
+		}
+
+		finder := NewReconcileFinder(fset)
+		for _, rf := range finder.FindReconcileFunctions([]*packages.Package{pkg}) {
+			if rf.Setup != nil {
+				NewPatternDetector(fset, pkg, src, "req").DetectSetupPatterns(rf.Setup)
+			}
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			detector := NewPatternDetector(fset, pkg, src, ExtractReqParamName(fn))
+			detector.indexedFields = detector.fieldIndexes(fn)
+			detector.DetectPatterns(fn)
+			NewPatternDetector(fset, pkg, src, "req").DetectSetupPatterns(fn)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// GoldenExt is the extension of the expected-output file stored next to
+// each detector fixture, e.g. list_unscoped.go and list_unscoped.golden.
+const GoldenExt = ".golden"
+
+// GoldenReconciler is the part of a reconciler's analysis a golden file
+// pins down. Snippets and descriptions are left out so wording changes do
+// not churn every golden.
+type GoldenReconciler struct {
+	ReceiverType      string                    `json:"receiver_type"`
+	Line              int                       `json:"line"`
+	Score             int                       `json:"score"`
+	Classification    string                    `json:"classification"`
+	Signals           []GoldenSignal            `json:"signals"`
+	ReadKinds         int                       `json:"read_kinds,omitempty"`
+	ControllerOptions *models.ControllerOptions `json:"controller_options,omitempty"`
+	Predicates        []string                  `json:"predicates,omitempty"`
+	ExternalEffects   []string                  `json:"external_effects,omitempty"`
+}
+
+// GoldenSignal is a signal as recorded in a golden file.
+type GoldenSignal struct {
+	Type  string `json:"type"`
+	Line  int    `json:"line"`
+	Score int    `json:"score"`
+}
+
+// GoldenMismatch is a fixture whose analysis differs from its golden file.
+type GoldenMismatch struct {
+	Fixture string
+	Want    string // golden file contents, "" if missing
+	Got     string
+}
+
+// CheckGoldens analyzes every .go fixture in dir on its own via
+// AnalyzeSource, as package "fixture" of SourceModule, and compares the
+// result against the fixture's sibling .golden file. With update set, the
+// golden files are rewritten instead and no mismatches are reported.
+func (a *Analyzer) CheckGoldens(dir string, update bool) ([]GoldenMismatch, error) {
+	fixtures, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures: %w", err)
+	}
+	sort.Strings(fixtures)
+
+	var mismatches []GoldenMismatch
+	for _, fixture := range fixtures {
+		got, err := a.goldenOutput(fixture)
+		if err != nil {
+			return nil, err
+		}
+
+		goldenPath := strings.TrimSuffix(fixture, ".go") + GoldenExt
+		if update {
+			if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+				return nil, fmt.Errorf("failed to write golden file: %w", err)
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(goldenPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read golden file: %w", err)
+		}
+		if !bytes.Equal(want, got) {
+			mismatches = append(mismatches, GoldenMismatch{Fixture: fixture, Want: string(want), Got: string(got)})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// goldenOutput analyzes a single fixture file and renders its golden JSON.
+func (a *Analyzer) goldenOutput(fixture string) ([]byte, error) {
+	src, err := os.ReadFile(fixture)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	reconcilers, err := a.AnalyzeSource(map[string]string{
+		"fixture/" + filepath.Base(fixture): string(src),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze fixture %s: %w", fixture, err)
+	}
+
+	golden := make([]GoldenReconciler, 0, len(reconcilers))
+	for _, r := range reconcilers {
+		g := GoldenReconciler{
+			ReceiverType:      r.ReceiverType,
+			Line:              r.Line,
+			Score:             r.Score,
+			Classification:    r.Classification,
+			Signals:           make([]GoldenSignal, 0, len(r.Signals)),
+			ReadKinds:         r.ReadKinds,
+			ControllerOptions: r.ControllerOptions,
+			Predicates:        r.Predicates,
+			ExternalEffects:   r.ExternalEffects,
+		}
+		for _, sig := range r.Signals {
+			g.Signals = append(g.Signals, GoldenSignal{Type: sig.Type, Line: sig.Line, Score: sig.Score})
+		}
+		golden = append(golden, g)
+	}
+	sort.Slice(golden, func(i, j int) bool { return golden[i].Line < golden[j].Line })
+
+	out, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal golden output: %w", err)
+	}
+	return append(out, '\n'), nil
+}
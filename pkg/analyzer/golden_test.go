@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the detector fixtures")
+
+// TestGoldens checks every detector fixture against its golden file. Run
+// with -update after an intended detector change and review the diff.
+func TestGoldens(t *testing.T) {
+	a := NewAnalyzer(t.TempDir(), false)
+	mismatches, err := a.CheckGoldens(filepath.Join("testdata", "fixtures"), *update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mismatches {
+		if m.Want == "" {
+			t.Errorf("%s: no golden file (run go test -update)", m.Fixture)
+			continue
+		}
+		t.Errorf("%s differs from its golden file:\n--- want\n%s+++ got\n%s", m.Fixture, m.Want, m.Got)
+	}
+}
+
+// unemitted lists catalog signals no detector emits yet, so no fixture can
+// produce them.
+var unemitted = map[string]bool{
+	models.SignalListOwnerScoped:   true,
+	models.SignalFinalizerHandling: true,
+	models.SignalBuildDesiredState: true,
+}
+
+// TestGoldensCoverCatalog checks that every emitted catalog signal is
+// produced by at least one detector fixture.
+func TestGoldensCoverCatalog(t *testing.T) {
+	goldens, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	covered := make(map[string]bool)
+	for _, golden := range goldens {
+		data, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var reconcilers []struct {
+			Signals []struct {
+				Type string `json:"type"`
+			} `json:"signals"`
+		}
+		if err := json.Unmarshal(data, &reconcilers); err != nil {
+			t.Fatalf("%s: %v", golden, err)
+		}
+		for _, r := range reconcilers {
+			for _, sig := range r.Signals {
+				covered[sig.Type] = true
+			}
+		}
+	}
+	for _, info := range models.SignalCatalog {
+		if !covered[info.Type] && !unemitted[info.Type] {
+			t.Errorf("no fixture produces signal %s", info.Type)
+		}
+		if covered[info.Type] && unemitted[info.Type] {
+			t.Errorf("signal %s is produced by a fixture but listed as unemitted", info.Type)
+		}
+	}
+}
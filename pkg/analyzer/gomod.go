@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+	"golang.org/x/mod/modfile"
+)
+
+// controllerRuntimeModule is the module path of controller-runtime.
+const controllerRuntimeModule = "sigs.k8s.io/controller-runtime"
+
+// readModuleInfo parses the go.mod at the repository root, from the overlay
+// or disk, and returns the
+// module path it declares and the controller-runtime version it requires.
+// A replace directive pinning controller-runtime to another version takes
+// precedence; a replacement with a local directory keeps the required
+// version. Either value is "" if absent. A missing go.mod is not an error.
+func (a *Analyzer) readModuleInfo(repoPath string) (string, string, error) {
+	goMod := filepath.Join(repoPath, "go.mod")
+	data, err := a.readFile(goMod)
+	if os.IsNotExist(err) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	// ParseLax drops replace directives, so it is only the fallback for
+	// go.mod files with directives newer than x/mod knows.
+	f, err := modfile.Parse(goMod, data, nil)
+	if err != nil {
+		if f, err = modfile.ParseLax(goMod, data, nil); err != nil {
+			return "", "", fmt.Errorf("failed to parse go.mod: %w", err)
+		}
+	}
+
+	var modPath, crVersion string
+	if f.Module != nil {
+		modPath = f.Module.Mod.Path
+	}
+	for _, req := range f.Require {
+		if req.Mod.Path == controllerRuntimeModule {
+			crVersion = req.Mod.Version
+		}
+	}
+	for _, rep := range f.Replace {
+		if rep.Old.Path == controllerRuntimeModule && rep.New.Version != "" {
+			crVersion = rep.New.Version
+		}
+	}
+	return modPath, crVersion, nil
+}
+
+// addModuleInfo records the repository's module path and controller-runtime
+// version in inv, logging rather than failing on a malformed go.mod.
+func (a *Analyzer) addModuleInfo(inv *models.RepoInventory, repoPath string) {
+	modPath, crVersion, err := a.readModuleInfo(repoPath)
+	if err != nil {
+		log.Printf("Error reading module info in %s: %v", repoPath, err)
+		return
+	}
+	inv.ModulePath = modPath
+	inv.ControllerRuntime = crVersion
+}
@@ -0,0 +1,59 @@
+package analyzer
+
+import "testing"
+
+func TestReadModuleInfo(t *testing.T) {
+	tests := []struct {
+		name             string
+		gomod            string
+		modPath, version string
+		wantErr          bool
+	}{
+		{
+			name:    "required",
+			gomod:   "module example.com/op\n\ngo 1.21\n\nrequire sigs.k8s.io/controller-runtime v0.17.2\n",
+			modPath: "example.com/op",
+			version: "v0.17.2",
+		},
+		{
+			name:    "replaced with a version",
+			gomod:   "module example.com/op\n\nrequire sigs.k8s.io/controller-runtime v0.17.2\n\nreplace sigs.k8s.io/controller-runtime => github.com/fork/controller-runtime v0.16.0\n",
+			modPath: "example.com/op",
+			version: "v0.16.0",
+		},
+		{
+			name:    "replaced with a directory",
+			gomod:   "module example.com/op\n\nrequire sigs.k8s.io/controller-runtime v0.17.2\n\nreplace sigs.k8s.io/controller-runtime => ../controller-runtime\n",
+			modPath: "example.com/op",
+			version: "v0.17.2",
+		},
+		{
+			name:    "no controller-runtime",
+			gomod:   "module example.com/op\n\ngo 1.21\n",
+			modPath: "example.com/op",
+		},
+		{
+			name:    "unknown directive",
+			gomod:   "module example.com/op\n\nfrobnicate on\n\nrequire sigs.k8s.io/controller-runtime v0.17.2\n",
+			modPath: "example.com/op",
+			version: "v0.17.2",
+		},
+		{name: "no go.mod"},
+		{name: "malformed", gomod: "module example.com/op\nrequire (\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{}
+			if tt.gomod != "" {
+				files["go.mod"] = tt.gomod
+			}
+			modPath, version, err := (&Analyzer{}).readModuleInfo(writeRepo(t, files))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readModuleInfo() error = %v, want error %t", err, tt.wantErr)
+			}
+			if modPath != tt.modPath || version != tt.version {
+				t.Errorf("readModuleInfo() = %q, %q, want %q, %q", modPath, version, tt.modPath, tt.version)
+			}
+		})
+	}
+}
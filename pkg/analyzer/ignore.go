@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// IgnoreFile is the name of the file at a repository root listing paths to
+// leave out of the survey.
+const IgnoreFile = ".surveyignore"
+
+// ignoreRules are gitignore-style patterns, one per line of an IgnoreFile.
+// A pattern containing a slash (other than a trailing one) is anchored at
+// the repository root; otherwise it matches a directory name at any depth.
+// "**" matches any number of directories. Negation is not supported.
+type ignoreRules []string
+
+// loadIgnoreRules reads the IgnoreFile at repoPath, from the overlay or
+// disk, if any.
+func (a *Analyzer) loadIgnoreRules(repoPath string) (ignoreRules, error) {
+	data, err := a.readFile(filepath.Join(repoPath, IgnoreFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFile, err)
+	}
+
+	var rules ignoreRules
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, line)
+	}
+	return rules, scanner.Err()
+}
+
+// Match reports whether the slash-separated directory dir, relative to the
+// repository root, or any of its parents is ignored.
+func (r ignoreRules) Match(dir string) bool {
+	if dir == "." || dir == "" {
+		return false
+	}
+	parts := strings.Split(dir, "/")
+	for i := range parts {
+		prefix := parts[:i+1]
+		for _, rule := range r {
+			rule = strings.TrimSuffix(rule, "/")
+			if strings.Contains(rule, "/") {
+				if matchSegments(strings.Split(strings.TrimPrefix(rule, "/"), "/"), prefix) {
+					return true
+				}
+			} else if ok, _ := path.Match(rule, prefix[i]); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" segment matches zero or more path segments.
+func matchSegments(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return len(segs) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pattern[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segs[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segs[1:])
+}
+
+// packageDir returns the directory of a package's files relative to the
+// repository root, or "" if the package has no files.
+func packageDir(repoPath string, pkg *packages.Package) string {
+	files := pkg.GoFiles
+	if len(files) == 0 {
+		files = pkg.CompiledGoFiles
+	}
+	if len(files) == 0 {
+		return ""
+	}
+	return path.Dir(repoRelPath(repoPath, files[0]))
+}
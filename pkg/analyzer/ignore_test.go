@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestIgnoreRulesMatch(t *testing.T) {
+	rules := ignoreRules{"testdata", "hack/", "/examples/*/legacy", "docs/**/samples", "gen-*"}
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{".", false},
+		{"controllers", false},
+		{"testdata", true},
+		{"pkg/controller/testdata/fixtures", true},
+		{"hack", true},
+		{"tools/hack", true},
+		{"examples/basic/legacy", true},
+		{"examples/basic/legacy/sub", true},
+		{"examples/legacy", false},
+		{"other/examples/basic/legacy", false},
+		{"docs/samples", true},
+		{"docs/v1/beta/samples", true},
+		{"samples", false},
+		{"pkg/gen-client", true},
+	}
+	for _, tt := range tests {
+		if got := rules.Match(tt.dir); got != tt.want {
+			t.Errorf("Match(%q) = %t, want %t", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestLoadIgnoreRules(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		IgnoreFile: "# generated code\n\ngen-*\n  hack/  \n",
+	})
+	rules, err := (&Analyzer{}).loadIgnoreRules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ignoreRules{"gen-*", "hack/"}); !reflect.DeepEqual(rules, want) {
+		t.Errorf("loadIgnoreRules() = %q, want %q", rules, want)
+	}
+
+	if rules, err := (&Analyzer{}).loadIgnoreRules(t.TempDir()); err != nil || rules != nil {
+		t.Errorf("loadIgnoreRules() without %s = %q, %v, want no rules", IgnoreFile, rules, err)
+	}
+}
+
+func TestIgnoreFile(t *testing.T) {
+	dir := writeRepo(t, map[string]string{
+		"go.mod":                   "module example.com/ignore\n\ngo 1.21\n",
+		IgnoreFile:                 "examples/\n",
+		"controllers/a/a.go":       reconcilerSource("a", "WidgetReconciler"),
+		"examples/sample/b/b.go":   reconcilerSource("b", "SampleReconciler"),
+		"internal/examples/c/c.go": reconcilerSource("c", "NestedReconciler"),
+	})
+	a := NewAnalyzer(t.TempDir(), false)
+	refs, err := a.ListReconcilers(models.Repository{URL: dir, LocalPath: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ref := range refs {
+		got = append(got, ref.ReceiverType)
+	}
+	sort.Strings(got)
+	if want := []string{"WidgetReconciler"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("found %v, want %v", got, want)
+	}
+}
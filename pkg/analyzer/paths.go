@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// repoRelPath returns file relative to the repository root, slash-separated.
+// The root may be relative or reached through symlinks. Files outside the
+// root, such as those of a module replaced with a path elsewhere, are named
+// by their module path (from the nearest go.mod) and their path inside that
+// module, so absolute filesystem paths never end up in results.
+func repoRelPath(repoPath, file string) string {
+	root := absPath(repoPath)
+	file = absPath(file)
+	if rel, ok := relPath(root, file); ok {
+		return rel
+	}
+	if rel, ok := relPath(resolvePath(root), resolvePath(file)); ok {
+		return rel
+	}
+
+	if modRoot, modPath := moduleRoot(filepath.Dir(file)); modRoot != "" {
+		if rel, ok := relPath(modRoot, file); ok {
+			if modPath == "" {
+				return rel
+			}
+			return path.Join(modPath, rel)
+		}
+	}
+	return filepath.Base(file)
+}
+
+// relPath returns target relative to base if target lies below base.
+func relPath(base, target string) (string, bool) {
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// absPath makes p absolute, leaving it unchanged on error.
+func absPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return p
+}
+
+// resolvePath resolves symlinks in p, or in its directory when p itself only
+// lives in an overlay.
+func resolvePath(p string) string {
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		return resolved
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(p)); err == nil {
+		return filepath.Join(dir, filepath.Base(p))
+	}
+	return p
+}
+
+// moduleRoot finds the nearest directory at or above dir containing a
+// go.mod, and the module path it declares.
+func moduleRoot(dir string) (string, string) {
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if line = strings.TrimSpace(line); strings.HasPrefix(line, "module ") {
+					return dir, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+				}
+			}
+			return dir, ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepoRelPath(t *testing.T) {
+	base := t.TempDir()
+	repo := filepath.Join(base, "repo")
+	writeFile := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(base, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("repo/controllers/foo.go", "package controllers\n")
+	writeFile("replaced/go.mod", "module \"example.com/replaced\"\n\ngo 1.21\n")
+	writeFile("replaced/api/types.go", "package api\n")
+	writeFile("unnamed/go.mod", "go 1.21\n")
+	writeFile("unnamed/api/types.go", "package api\n")
+	link := filepath.Join(base, "link")
+	if err := os.Symlink(repo, link); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	relRepo, err := filepath.Rel(wd, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		repo, file string
+		want       string
+	}{
+		{name: "below the root", repo: repo, file: filepath.Join(repo, "controllers/foo.go"), want: "controllers/foo.go"},
+		{name: "relative root", repo: relRepo, file: filepath.Join(repo, "controllers/foo.go"), want: "controllers/foo.go"},
+		{name: "root through a symlink", repo: link, file: filepath.Join(repo, "controllers/foo.go"), want: "controllers/foo.go"},
+		{name: "file through a symlink", repo: repo, file: filepath.Join(link, "controllers/foo.go"), want: "controllers/foo.go"},
+		{name: "overlay file", repo: link, file: filepath.Join(repo, "controllers/bar.go"), want: "controllers/bar.go"},
+		{name: "replaced module", repo: repo, file: filepath.Join(base, "replaced/api/types.go"), want: "example.com/replaced/api/types.go"},
+		{name: "module without a path", repo: repo, file: filepath.Join(base, "unnamed/api/types.go"), want: "api/types.go"},
+		{name: "outside any module", repo: repo, file: filepath.Join(base, "stray.go"), want: "stray.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repoRelPath(tt.repo, tt.file); got != tt.want {
+				t.Errorf("repoRelPath(%q, %q) = %q, want %q", tt.repo, tt.file, got, tt.want)
+			}
+		})
+	}
+}
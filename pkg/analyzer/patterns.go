@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/format"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rg0now/k8s-controller-survey/pkg/models"
 	"golang.org/x/tools/go/packages"
@@ -23,10 +28,107 @@ type PatternDetector struct {
 	// Track the request parameter name (usually "req" or "request").
 	reqParamName string
 
+	// Receiver name of the analyzed method, if any.
+	recvName string
+
 	// Track possible client field names.
 	clientFieldNames []string
+
+	// Local variables whose value is derived from the request parameter.
+	reqDerived map[string]bool
+
+	// List variables filled by a List scoped by request-derived selectors.
+	scopedLists map[string]bool
+
+	// List variables filled by any client List.
+	listed map[string]bool
+
+	// Keys of the field indexes registered in the controller's setup function.
+	indexedFields map[string]bool
+
+	// Manual get-or-create sequences: the deciding IsNotFound if statements,
+	// and the Get/Create/Update calls they collapse, plus writes of the
+	// upserted object inside controllerutil mutate functions.
+	upsertIfs   map[*ast.IfStmt]bool
+	upsertCalls map[token.Pos]bool
+
+	// Set when objects are read through meta.Accessor or unstructured helpers,
+	// or their kinds are resolved through a RESTMapper or scheme.
+	generic bool
+
+	// Set when the function performs no client writes.
+	readOnly bool
+
+	// Set when an apply configuration is sent with an Apply call.
+	applies bool
+
+	// Set when status conditions are maintained via meta.SetStatusCondition.
+	hasConditions bool
+
+	// Set when owner references are put on written objects.
+	managesChildren bool
+
+	// Set when a paused annotation gates an early return.
+	respectsPause bool
+
+	// Set when watches are established from Reconcile via an object tracker.
+	dynamicWatches bool
+
+	// Set when a hash of the desired state is compared with a stored one.
+	hasHashGate bool
+
+	// Set once a DeepEqual drift check was reported.
+	hasDriftCheck bool
+
+	// Calls acting outside the Kubernetes API, e.g. "exec.Command".
+	externalEffects []string
+
+	// Number of distinct kinds read through Get or List.
+	readKinds int
+
+	// Places where type resolution fell back to name heuristics.
+	warnings []string
+
+	// Requeue behavior of the function's return statements.
+	requeuesAlways bool
+	neverRequeues  bool
+
+	// Reconciliation direction (see models.Direction*).
+	direction string
+
+	// Types discovered from the controller's setup function.
+	primaryType  string
+	watchedTypes []string
+
+	// Controller options set in the setup function, if any.
+	controllerOptions *models.ControllerOptions
+
+	// Built-in change predicates used in the setup function.
+	predicates []string
+
+	// SnippetLength caps snippet length in bytes; 0 disables truncation.
+	SnippetLength int
+
+	// FormatSnippets keeps snippets multi-line and gofmt-formatted instead
+	// of collapsing them onto a single line.
+	FormatSnippets bool
+
+	// NeutralKinds are listed kinds whose List signals score 0, since
+	// listing them is diagnostic rather than state-of-the-world.
+	NeutralKinds []string
+
+	// Detectors run in order over the function; defaults to
+	// RegisteredDetectors, whose first is the built-in body walk.
+	Detectors []Detector
 }
 
+// DefaultSnippetLength is the default maximum snippet length.
+const DefaultSnippetLength = 200
+
+// DefaultNeutralKinds are the kinds listed for observability or
+// coordination only.
+var DefaultNeutralKinds = []string{"Event", "Lease", "EndpointSlice", "Endpoints"}
+
 // NewPatternDetector creates a new PatternDetector.
 func NewPatternDetector(fset *token.FileSet, pkg *packages.Package, fileData []byte, reqParamName string) *PatternDetector {
 	return &PatternDetector{
@@ -35,6 +137,14 @@ func NewPatternDetector(fset *token.FileSet, pkg *packages.Package, fileData []b
 		fileData:         fileData,
 		reqParamName:     reqParamName,
 		clientFieldNames: []string{"Client", "client", "c"},
+		reqDerived:       make(map[string]bool),
+		scopedLists:      make(map[string]bool),
+		listed:           make(map[string]bool),
+		upsertIfs:        make(map[*ast.IfStmt]bool),
+		upsertCalls:      make(map[token.Pos]bool),
+		SnippetLength:    DefaultSnippetLength,
+		NeutralKinds:     DefaultNeutralKinds,
+		Detectors:        RegisteredDetectors(),
 	}
 }
 
@@ -46,8 +156,42 @@ func (pd *PatternDetector) DetectPatterns(fn *ast.FuncDecl) []models.Signal {
 		return signals
 	}
 
-	// Walk the function body.
-	ast.Inspect(fn.Body, func(n ast.Node) bool {
+	if pd.pkg == nil || pd.pkg.TypesInfo == nil {
+		pd.warnf("no type information; all signals use name-based heuristics")
+	}
+	if fn.Recv != nil && len(fn.Recv.List) > 0 && len(fn.Recv.List[0].Names) > 0 {
+		pd.recvName = fn.Recv.List[0].Names[0].Name
+	}
+
+	// Record locals derived from the request before detecting patterns.
+	pd.collectReqDerived(fn.Body)
+	pd.collectUpserts(fn.Body)
+
+	// Run the built-in, registered and custom detectors.
+	for _, d := range pd.Detectors {
+		ctx := DetectContext{
+			Fset:         pd.fset,
+			Pkg:          pd.pkg,
+			ReqParamName: pd.reqParamName,
+			Signals:      signals,
+			pd:           pd,
+		}
+		signals = append(signals, d.Detect(fn, ctx)...)
+	}
+
+	pd.readKinds = len(readKinds(signals))
+	pd.detectRequeueBehavior(fn.Body)
+	pd.detectDirection(fn.Body)
+	pd.readOnly = !pd.hasWriteOperation(fn.Body) && !pd.applies
+
+	return signals
+}
+
+// walkBody emits the signals of individual calls, if statements, loops and
+// channel sends, in source order. It is the first built-in detector.
+func (pd *PatternDetector) walkBody(body *ast.BlockStmt) []models.Signal {
+	var signals []models.Signal
+	ast.Inspect(body, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.CallExpr:
 			sigs := pd.detectCallPatterns(node)
@@ -61,39 +205,867 @@ func (pd *PatternDetector) DetectPatterns(fn *ast.FuncDecl) []models.Signal {
 		case *ast.RangeStmt:
 			sigs := pd.detectRangeLoopPatterns(node)
 			signals = append(signals, sigs...)
+		case *ast.SendStmt:
+			if sig := pd.analyzeSendStmt(node); sig.Type != "" {
+				signals = append(signals, sig)
+			}
+		case *ast.CompositeLit:
+			if sel, ok := node.Type.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Unstructured") &&
+				pd.isPkgSelector(sel, unstructuredPkgPath, "unstructured") {
+				pd.generic = true
+			}
+		}
+		return true
+	})
+	return signals
+}
+
+// detectDirection classifies the function as a status writer (reads Spec,
+// writes only Status) or an orchestrator (writes Spec or whole objects),
+// based on field selectors in assignments and the targets of client writes.
+func (pd *PatternDetector) detectDirection(body *ast.BlockStmt) {
+	specReads, specWrites, statusWrites, objectWrites := 0, 0, 0, 0
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				switch {
+				case selectsField(lhs, "Status"):
+					statusWrites++
+				case selectsField(lhs, "Spec"):
+					specWrites++
+				}
+			}
+			for _, rhs := range node.Rhs {
+				if selectsField(rhs, "Spec") {
+					specReads++
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || !pd.isClientCall(sel) {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "Create", "Update", "Delete", "DeleteAllOf", "Patch":
+				if isStatusSubresource(sel) {
+					statusWrites++
+				} else {
+					objectWrites++
+				}
+			}
+		case *ast.IfStmt:
+			if selectsField(node.Cond, "Spec") {
+				specReads++
+			}
 		}
 		return true
 	})
 
-	return signals
-}
+	switch {
+	case objectWrites > 0 || specWrites > 0:
+		pd.direction = models.DirectionOrchestrator
+	case statusWrites > 0 && specReads > 0:
+		pd.direction = models.DirectionStatus
+	}
+}
+
+// selectsField checks if expr contains a selector for the given field name.
+func selectsField(expr ast.Expr, field string) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == field {
+			found = true
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+// isStatusSubresource checks if a write goes through the status
+// subresource, e.g. r.Status().Update(...) or r.SubResource("status").Patch(...).
+func isStatusSubresource(sel *ast.SelectorExpr) bool {
+	call, ok := sel.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	inner, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	switch inner.Sel.Name {
+	case "Status":
+		return true
+	case "SubResource":
+		if len(call.Args) == 1 {
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+				return lit.Value == `"status"`
+			}
+		}
+	}
+	return false
+}
+
+// Direction returns the reconciliation direction of the last analyzed
+// function, or "" if it performs no writes.
+func (pd *PatternDetector) Direction() string {
+	return pd.direction
+}
+
+// detectRequeueBehavior records whether the function's returns always or never
+// request a requeue. Returns whose Result can't be determined statically
+// (e.g. a variable or a helper call) make both answers unknown.
+func (pd *PatternDetector) detectRequeueBehavior(body *ast.BlockStmt) {
+	requeue, noRequeue, unknown := 0, 0, 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// Closures return their own results.
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				unknown++
+				return true
+			}
+			switch pd.resultRequeues(node.Results[0]) {
+			case requeueYes:
+				requeue++
+			case requeueNo:
+				noRequeue++
+			default:
+				unknown++
+			}
+		}
+		return true
+	})
+
+	pd.requeuesAlways = requeue > 0 && noRequeue == 0 && unknown == 0
+	pd.neverRequeues = noRequeue > 0 && requeue == 0 && unknown == 0
+}
+
+// detectImmediateRequeue reports returns of Result{Requeue: true}, an
+// unconditional immediate requeue typical of work-in-progress reconciles.
+// The signal is emitted once, at the first such return.
+func (pd *PatternDetector) detectImmediateRequeue(body *ast.BlockStmt) models.Signal {
+	var first *ast.ReturnStmt
+	count := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) > 0 && requeuesImmediately(node.Results[0]) {
+				if first == nil {
+					first = node
+				}
+				count++
+			}
+		}
+		return true
+	})
+	if first == nil {
+		return models.Signal{}
+	}
+
+	return models.Signal{
+		Type:        models.SignalRequeueImmediate,
+		Line:        pd.fset.Position(first.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalRequeueImmediate),
+		Snippet:     pd.extractSnippet(first),
+		Description: fmt.Sprintf("Immediate requeue via Result{Requeue: true} (%d returns)", count),
+	}
+}
+
+// requeuesImmediately checks if expr is a Result literal with Requeue: true.
+func requeuesImmediately(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != "Requeue" {
+			continue
+		}
+		if ident, ok := kv.Value.(*ast.Ident); ok && ident.Name == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// broadReadKinds is the number of distinct kinds read at which a function
+// is scored as having a broad read surface.
+const broadReadKinds = 4
+
+// detectBroadReads reports a function reading broadReadKinds or more
+// distinct kinds, as an orchestrator consulting many caches does.
+func (pd *PatternDetector) detectBroadReads(fn *ast.FuncDecl, signals []models.Signal) models.Signal {
+	kinds := readKinds(signals)
+	if len(kinds) < broadReadKinds {
+		return models.Signal{}
+	}
+	return models.Signal{
+		Type:        models.SignalBroadReadSurface,
+		Line:        pd.fset.Position(fn.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalBroadReadSurface),
+		Description: fmt.Sprintf("Reads %d distinct kinds (%s): broad read surface", len(kinds), strings.Join(kinds, ", ")),
+	}
+}
+
+// readKinds returns the sorted distinct object kinds of read signals.
+func readKinds(signals []models.Signal) []string {
+	seen := make(map[string]bool)
+	var kinds []string
+	for _, sig := range signals {
+		if sig.ObjectKind == "" || seen[sig.ObjectKind] {
+			continue
+		}
+		if info, ok := models.LookupSignal(sig.Type); !ok || info.Category != models.CategoryRead {
+			continue
+		}
+		seen[sig.ObjectKind] = true
+		kinds = append(kinds, sig.ObjectKind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// externalCalls lists package functions that act outside the Kubernetes
+// API, with the import path and usual name of their package.
+var externalCalls = []struct {
+	path, name string
+	funcs      []string
+}{
+	{"os/exec", "exec", []string{"Command", "CommandContext"}},
+	{"net/http", "http", []string{"Get", "Head", "Post", "PostForm"}},
+	{"net", "net", []string{"Dial", "DialTimeout"}},
+	{"google.golang.org/grpc", "grpc", []string{"Dial", "DialContext", "NewClient"}},
+}
+
+// externalEffect returns the name of a call acting outside the Kubernetes
+// API, e.g. "exec.Command" or "http.Client.Do", or "" if sel is none.
+func (pd *PatternDetector) externalEffect(sel *ast.SelectorExpr) string {
+	for _, c := range externalCalls {
+		for _, f := range c.funcs {
+			if sel.Sel.Name == f && pd.isPkgSelector(sel, c.path, c.name) {
+				return c.name + "." + f
+			}
+		}
+	}
+	switch sel.Sel.Name {
+	case "Do", "Get", "Head", "Post", "PostForm":
+		if pd.isHTTPClient(sel.X, sel.Sel.Name) {
+			return "http.Client." + sel.Sel.Name
+		}
+	}
+	return ""
+}
+
+// isHTTPClient checks if expr is an http.Client or a pointer to one. Without
+// type info only Do calls on an expression mentioning http match, as in
+// r.httpClient.Do(req), since Get is also a client method.
+func (pd *PatternDetector) isHTTPClient(expr ast.Expr, method string) bool {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(expr); t != nil && t != types.Typ[types.Invalid] {
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			named, ok := types.Unalias(t).(*types.Named)
+			return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "net/http" && named.Obj().Name() == "Client"
+		}
+	}
+	if method == "Do" && strings.Contains(strings.ToLower(types.ExprString(expr)), "http") {
+		pd.warnf("line %d: %s.Do matched as an HTTP request by name", pd.fset.Position(expr.Pos()).Line, types.ExprString(expr))
+		return true
+	}
+	return false
+}
+
+// addExternalEffect records an external call once.
+func (pd *PatternDetector) addExternalEffect(effect string) {
+	for _, e := range pd.externalEffects {
+		if e == effect {
+			return
+		}
+	}
+	pd.externalEffects = append(pd.externalEffects, effect)
+}
+
+// hashPkgs maps the import paths of hashing packages to their usual names.
+var hashPkgs = map[string]string{
+	"hash/fnv":                              "fnv",
+	"hash/crc32":                            "crc32",
+	"crypto/md5":                            "md5",
+	"crypto/sha1":                           "sha1",
+	"crypto/sha256":                         "sha256",
+	"github.com/mitchellh/hashstructure":    "hashstructure",
+	"github.com/mitchellh/hashstructure/v2": "hashstructure",
+}
+
+// detectHashGate finds a hash of the desired state compared against one
+// stored in an annotation, as in
+//
+//	hash := computeHash(obj.Spec)
+//	if obj.Annotations[specHashKey] == hash { return }
+//
+// Such controllers skip work when the input is unchanged. The hash may come
+// from fnv, crc32, md5, sha1, sha256 or hashstructure, or from a helper
+// whose name mentions hashing, directly or through locals; the stored
+// value from an annotation index, directly or through a local.
+func (pd *PatternDetector) detectHashGate(body *ast.BlockStmt) models.Signal {
+	hashes := taintedLocals(body, pd.isHashExpr)
+	stored := taintedLocals(body, func(expr ast.Expr) bool {
+		index, ok := expr.(*ast.IndexExpr)
+		return ok && isAnnotationMap(index.X)
+	})
+	isHash := func(expr ast.Expr) bool { return pd.isHashExpr(expr) || mentionsLocal(expr, hashes) }
+	isStored := func(expr ast.Expr) bool {
+		if index, ok := expr.(*ast.IndexExpr); ok && isAnnotationMap(index.X) {
+			return true
+		}
+		ident, ok := expr.(*ast.Ident)
+		return ok && stored[ident.Name]
+	}
+
+	var sig models.Signal
+	ast.Inspect(body, func(n ast.Node) bool {
+		if sig.Type != "" {
+			return false
+		}
+		ifStmt, ok := n.(*ast.IfStmt)
+		if !ok {
+			return true
+		}
+		ast.Inspect(ifStmt.Cond, func(c ast.Node) bool {
+			bin, ok := c.(*ast.BinaryExpr)
+			if !ok || (bin.Op != token.EQL && bin.Op != token.NEQ) {
+				return sig.Type == ""
+			}
+			if (isHash(bin.X) && isStored(bin.Y)) || (isStored(bin.X) && isHash(bin.Y)) {
+				pd.hasHashGate = true
+				sig = models.Signal{
+					Type:        models.SignalHashGate,
+					Line:        pd.fset.Position(ifStmt.Pos()).Line,
+					Score:       models.DefaultScore(models.SignalHashGate),
+					Snippet:     pd.extractSnippet(ifStmt),
+					Description: "Hash of the desired state compared with one stored in an annotation (skips unchanged input)",
+				}
+			}
+			return sig.Type == ""
+		})
+		return true
+	})
+	return sig
+}
+
+// isHashExpr checks if expr calls a hashing package, or a function whose
+// name mentions hashing, e.g. util.ComputeHash(spec).
+func (pd *PatternDetector) isHashExpr(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found {
+			return !found
+		}
+		var name string
+		switch f := call.Fun.(type) {
+		case *ast.Ident:
+			name = f.Name
+		case *ast.SelectorExpr:
+			name = f.Sel.Name
+			for path, pkgName := range hashPkgs {
+				if pd.isPkgSelector(f, path, pkgName) {
+					found = true
+					return false
+				}
+			}
+		}
+		if strings.Contains(strings.ToLower(name), "hash") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// taintedLocals returns the locals of body assigned from an expression
+// matching match, or from an expression using such a local.
+func taintedLocals(body *ast.BlockStmt, match func(ast.Expr) bool) map[string]bool {
+	locals := make(map[string]bool)
+	taint := func(name *ast.Ident, value ast.Expr) {
+		if name.Name != "_" && (match(value) || mentionsLocal(value, locals)) {
+			locals[name.Name] = true
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				// Multi-value assignments (x, ok := m[k]) share one RHS.
+				rhs := node.Rhs[0]
+				if len(node.Rhs) == len(node.Lhs) {
+					rhs = node.Rhs[i]
+				}
+				taint(ident, rhs)
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if len(node.Values) == 0 {
+					continue
+				}
+				value := node.Values[0]
+				if len(node.Values) == len(node.Names) {
+					value = node.Values[i]
+				}
+				taint(name, value)
+			}
+		}
+		return true
+	})
+	return locals
+}
+
+// mentionsLocal checks if expr uses any of the given locals.
+func mentionsLocal(expr ast.Expr, locals map[string]bool) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && locals[ident.Name] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// detectTimeDriven finds if statements whose condition compares against the
+// wall clock (time.Now, time.Since, time.Until, or a local assigned from
+// them) and whose branches write or requeue, as in renewing a certificate
+// near its expiry. Such decisions fire on time passing, not on watch events.
+func (pd *PatternDetector) detectTimeDriven(body *ast.BlockStmt) models.Signal {
+	clockVars := make(map[string]bool)
+	var first *ast.IfStmt
+	count := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i < len(node.Lhs) && pd.readsClock(rhs, clockVars) {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+						clockVars[ident.Name] = true
+					}
+				}
+			}
+		case *ast.IfStmt:
+			if !pd.readsClock(node.Cond, clockVars) || !pd.writesOrRequeues(node) {
+				return true
+			}
+			if first == nil {
+				first = node
+			}
+			count++
+		}
+		return true
+	})
+	if first == nil {
+		return models.Signal{}
+	}
+
+	return models.Signal{
+		Type:        models.SignalTimeDriven,
+		Line:        pd.fset.Position(first.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalTimeDriven),
+		Snippet:     pd.extractSnippet(first.Cond),
+		Description: fmt.Sprintf("Write or requeue gated on the current time (%d decisions)", count),
+	}
+}
+
+// readsClock checks if expr calls time.Now, time.Since or time.Until, or
+// uses a local assigned from them.
+func (pd *PatternDetector) readsClock(expr ast.Expr, clockVars map[string]bool) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.Ident:
+			found = found || clockVars[node.Name]
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && pd.isPkgSelector(sel, "time", "time") {
+				switch sel.Sel.Name {
+				case "Now", "Since", "Until":
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// writesOrRequeues checks if either branch of ifStmt performs a client write
+// or returns a requeueing Result.
+func (pd *PatternDetector) writesOrRequeues(ifStmt *ast.IfStmt) bool {
+	branches := []ast.Stmt{ifStmt.Body}
+	if ifStmt.Else != nil {
+		branches = append(branches, ifStmt.Else)
+	}
+	for _, branch := range branches {
+		block := &ast.BlockStmt{List: []ast.Stmt{branch}}
+		if pd.hasWriteOperation(block) {
+			return true
+		}
+		requeues := false
+		ast.Inspect(block, func(n ast.Node) bool {
+			if ret, ok := n.(*ast.ReturnStmt); ok && len(ret.Results) > 0 && pd.resultRequeues(ret.Results[0]) == requeueYes {
+				requeues = true
+			}
+			return !requeues
+		})
+		if requeues {
+			return true
+		}
+	}
+	return false
+}
+
+type requeueKind int
+
+const (
+	requeueUnknown requeueKind = iota
+	requeueYes
+	requeueNo
+)
+
+// resultRequeues inspects a returned Result literal for Requeue/RequeueAfter.
+func (pd *PatternDetector) resultRequeues(expr ast.Expr) requeueKind {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return requeueUnknown
+	}
+
+	var typeName string
+	switch t := lit.Type.(type) {
+	case *ast.Ident:
+		typeName = t.Name
+	case *ast.SelectorExpr:
+		typeName = t.Sel.Name
+	}
+	if !strings.Contains(typeName, "Result") {
+		return requeueUnknown
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return requeueUnknown
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Requeue":
+			if ident, ok := kv.Value.(*ast.Ident); !ok || ident.Name != "false" {
+				return requeueYes
+			}
+		case "RequeueAfter":
+			if basic, ok := kv.Value.(*ast.BasicLit); !ok || basic.Value != "0" {
+				return requeueYes
+			}
+		}
+	}
+
+	return requeueNo
+}
+
+// RequeuesAlways reports whether every return of the last analyzed function
+// requests a requeue.
+func (pd *PatternDetector) RequeuesAlways() bool {
+	return pd.requeuesAlways
+}
+
+// NeverRequeues reports whether no return of the last analyzed function
+// requests a requeue.
+func (pd *PatternDetector) NeverRequeues() bool {
+	return pd.neverRequeues
+}
+
+// ReadOnly reports whether the last analyzed function performs no client
+// writes, as in observer controllers that only read and record metrics.
+func (pd *PatternDetector) ReadOnly() bool {
+	return pd.readOnly
+}
+
+// Warnings returns the places where the last analyzed function's type
+// resolution fell back to name heuristics, making its signals less reliable.
+func (pd *PatternDetector) Warnings() []string {
+	return pd.warnings
+}
+
+// warnf records a warning once.
+func (pd *PatternDetector) warnf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	for _, w := range pd.warnings {
+		if w == msg {
+			return
+		}
+	}
+	pd.warnings = append(pd.warnings, msg)
+}
+
+// ManagesChildren reports whether the last analyzed function set owner
+// references on objects, i.e. manages owned children.
+func (pd *PatternDetector) ManagesChildren() bool {
+	return pd.managesChildren
+}
+
+// RespectsPause reports whether the last analyzed function returns early
+// when a paused annotation is set.
+func (pd *PatternDetector) RespectsPause() bool {
+	return pd.respectsPause
+}
+
+// DynamicWatches reports whether the last analyzed function establishes
+// watches at runtime, e.g. through cluster-api's external.ObjectTracker.
+func (pd *PatternDetector) DynamicWatches() bool {
+	return pd.dynamicWatches
+}
+
+// ReadKinds reports how many distinct kinds the last analyzed function read
+// through client Get/List calls or listers.
+func (pd *PatternDetector) ReadKinds() int {
+	return pd.readKinds
+}
+
+// ExternalEffects returns the calls of the last analyzed function that act
+// outside the Kubernetes API, such as processes or HTTP requests, in source
+// order. The signals do not see their effects.
+func (pd *PatternDetector) ExternalEffects() []string {
+	return pd.externalEffects
+}
+
+// HasHashGate reports whether the last analyzed function compared a hash of
+// its input against one stored in an annotation.
+func (pd *PatternDetector) HasHashGate() bool {
+	return pd.hasHashGate
+}
+
+// HasConditions reports whether the last analyzed function set status
+// conditions through the meta condition helpers.
+func (pd *PatternDetector) HasConditions() bool {
+	return pd.hasConditions
+}
+
+// IsGeneric reports whether the last analyzed function read objects through
+// meta.Accessor or unstructured helpers rather than typed fields, or
+// resolved their kinds at runtime.
+func (pd *PatternDetector) IsGeneric() bool {
+	return pd.generic
+}
+
+// detectCallPatterns detects client.List, client.Get, etc.
+func (pd *PatternDetector) detectCallPatterns(call *ast.CallExpr) []models.Signal {
+	var signals []models.Signal
+
+	// Get the method being called.
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return signals
+	}
+
+	methodName := sel.Sel.Name
+
+	// Record calls acting outside the Kubernetes API; they do not score.
+	if effect := pd.externalEffect(sel); effect != "" {
+		pd.addExternalEffect(effect)
+		return signals
+	}
+
+	// Check for untyped reads via meta.Accessor or unstructured.Nested* helpers.
+	if (pd.isPkgSelector(sel, metaPkgPath, "meta") && methodName == "Accessor") ||
+		(pd.isPkgSelector(sel, unstructuredPkgPath, "unstructured") && strings.HasPrefix(methodName, "Nested")) {
+		pd.generic = true
+		return signals
+	}
+
+	// Check for status reconciliation via the condition helpers.
+	if methodName == "SetStatusCondition" && pd.isPkgSelector(sel, metaPkgPath, "meta") {
+		pd.hasConditions = true
+		signals = append(signals, models.Signal{
+			Type:        models.SignalStatusCondition,
+			Line:        pd.fset.Position(call.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalStatusCondition),
+			Snippet:     pd.extractSnippet(call),
+			Description: "Status condition set via meta.SetStatusCondition",
+		})
+		return signals
+	}
+
+	// Check for owner references put on children before writing them.
+	if (methodName == "SetControllerReference" || methodName == "SetOwnerReference") &&
+		(pd.isPkgSelector(sel, controllerutilPkgPath, "controllerutil") || pd.isPkgSelector(sel, ctrlPkgPath, "ctrl")) {
+		pd.managesChildren = true
+		signals = append(signals, models.Signal{
+			Type:        models.SignalOwnerReference,
+			Line:        pd.fset.Position(call.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalOwnerReference),
+			Snippet:     pd.extractSnippet(call),
+			Description: fmt.Sprintf("%s on a child object (owner-based management)", methodName),
+		})
+		return signals
+	}
+
+	// Check for requests enqueued manually into a workqueue.
+	if pd.isWorkqueueAdd(sel) {
+		signals = append(signals, models.Signal{
+			Type:        models.SignalManualEnqueue,
+			Line:        pd.fset.Position(call.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalManualEnqueue),
+			Snippet:     pd.extractSnippet(call),
+			Description: fmt.Sprintf("Manual workqueue %s from Reconcile (fan-out to other objects)", methodName),
+		})
+		return signals
+	}
+
+	// Check for watches on referenced objects started from Reconcile.
+	if pd.isTrackerWatch(sel) {
+		pd.dynamicWatches = true
+		signals = append(signals, models.Signal{
+			Type:        models.SignalDynamicWatch,
+			Line:        pd.fset.Position(call.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalDynamicWatch),
+			Snippet:     pd.extractSnippet(call),
+			Description: "Object tracker Watch from Reconcile (watches established at runtime)",
+		})
+		return signals
+	}
+
+	// Check for kinds resolved at runtime through a RESTMapper or scheme.
+	if pd.isGVKLookup(sel) {
+		pd.generic = true
+		signals = append(signals, models.Signal{
+			Type:        models.SignalDynamicGVK,
+			Line:        pd.fset.Position(call.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalDynamicGVK),
+			Snippet:     pd.extractSnippet(call),
+			Description: fmt.Sprintf("%s resolves kinds at runtime (generic meta-controller)", methodName),
+		})
+		return signals
+	}
+
+	// Check for synchronous polling via k8s.io/apimachinery/pkg/util/wait.
+	if pd.isPkgSelector(sel, waitPkgPath, "wait") {
+		if sig := pd.analyzeWaitCall(call, methodName); sig.Type != "" {
+			signals = append(signals, sig)
+		}
+		return signals
+	}
+
+	// Check for optimistic-concurrency retries around writes.
+	if (methodName == "RetryOnConflict" || methodName == "OnError") && pd.isPkgSelector(sel, retryPkgPath, "retry") {
+		if sig := pd.analyzeRetryCall(call, methodName); sig.Type != "" {
+			signals = append(signals, sig)
+		}
+		return signals
+	}
+
+	// Check for desired-vs-actual comparisons of whole objects.
+	if pd.isDeepEqual(sel) {
+		if sig := pd.analyzeDeepEqual(call); sig.Type != "" {
+			signals = append(signals, sig)
+		}
+		return signals
+	}
 
-// detectCallPatterns detects client.List, client.Get, etc.
-func (pd *PatternDetector) detectCallPatterns(call *ast.CallExpr) []models.Signal {
-	var signals []models.Signal
+	// Check for declarative SSA through a typed client's Apply method, e.g.
+	// clientset.AppsV1().Deployments(ns).Apply(ctx, deploymentAC, opts).
+	if methodName == "Apply" && len(call.Args) >= 2 {
+		if kind, ok := pd.applyConfigurationKind(call.Args[1]); ok {
+			pd.applies = true
+			signals = append(signals, models.Signal{
+				Type:        models.SignalServerSideApply,
+				Line:        pd.fset.Position(call.Pos()).Line,
+				Score:       models.DefaultScore(models.SignalServerSideApply),
+				Snippet:     pd.extractSnippet(call),
+				Description: fmt.Sprintf("Apply of a %sApplyConfiguration (declarative server-side apply)", kind),
+				ObjectKind:  kind,
+			})
+			return signals
+		}
+	}
 
-	// Get the method being called.
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok {
+	// Check for cache snapshots through a client-go lister.
+	if methodName == "List" && len(call.Args) == 1 && (pd.isLabelSelector(call.Args[0]) || pd.isLister(sel.X)) {
+		if sig := pd.analyzeListerList(call, sel); sig.Type != "" {
+			signals = append(signals, sig)
+		}
 		return signals
 	}
 
-	methodName := sel.Sel.Name
+	// Check for controllerutil upserts. The walk still descends into the
+	// mutate function, so unrelated calls there are detected as usual.
+	if pd.isUpsertHelper(sel) {
+		signals = append(signals, models.Signal{
+			Type:        models.SignalCreateOrUpdate,
+			Line:        pd.fset.Position(call.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalCreateOrUpdate),
+			Snippet:     pd.extractSnippet(call),
+			Description: fmt.Sprintf("controllerutil.%s (single upsert)", methodName),
+		})
+		return signals
+	}
 
 	// Check if this is a client method call.
 	if !pd.isClientCall(sel) {
 		return signals
 	}
 
+	// Calls of a manual get-or-create are reported once, at their if statement.
+	if pd.upsertCalls[call.Pos()] {
+		return signals
+	}
+
 	switch methodName {
 	case "List":
+		if len(call.Args) < 2 {
+			return signals // malformed
+		}
+		if name := rootIdent(call.Args[1]); name != "" {
+			pd.listed[name] = true
+		}
+		kind := pd.listKind(call.Args[1])
 		sig := pd.analyzeListCall(call)
+		switch sig.Type {
+		case models.SignalListLabelScoped, models.SignalListFieldIndexed:
+			// Remember the list so loops over its items can be scored as
+			// fan-out over the request's own children.
+			if name := rootIdent(call.Args[1]); name != "" {
+				pd.scopedLists[name] = true
+			}
+		}
 		if sig.Type != "" {
-			signals = append(signals, sig)
+			signals = append(signals, pd.neutralizeKind(sig, kind))
+		}
+		if sig := pd.analyzeListPagination(call); sig.Type != "" {
+			signals = append(signals, pd.neutralizeKind(sig, kind))
 		}
 	case "Get":
 		sig := pd.analyzeGetCall(call)
 		if sig.Type != "" {
+			sig.ObjectKind = pd.listKind(call.Args[2])
 			signals = append(signals, sig)
 		}
 	case "Create", "Update", "Delete", "Patch":
@@ -101,11 +1073,56 @@ func (pd *PatternDetector) detectCallPatterns(call *ast.CallExpr) []models.Signa
 		if sig.Type != "" {
 			signals = append(signals, sig)
 		}
+	case "DeleteAllOf":
+		sig := pd.analyzeDeleteAllOfCall(call)
+		if sig.Type != "" {
+			signals = append(signals, pd.neutralizeKind(sig, pd.listKind(call.Args[1])))
+		}
 	}
 
 	return signals
 }
 
+// listKind returns the kind held by a List target such as &corev1.EventList{}
+// or a variable of that type, e.g. "Event", or "" if it is unknown.
+func (pd *PatternDetector) listKind(expr ast.Expr) string {
+	var name string
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(expr); t != nil {
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if named, ok := t.(*types.Named); ok {
+				name = named.Obj().Name()
+			}
+		}
+	}
+	if name == "" {
+		if unary, ok := expr.(*ast.UnaryExpr); ok {
+			expr = unary.X
+		}
+		if lit, ok := expr.(*ast.CompositeLit); ok && lit.Type != nil {
+			name = types.ExprString(lit.Type)
+			name = name[strings.LastIndex(name, ".")+1:]
+		}
+	}
+	return strings.TrimSuffix(name, "List")
+}
+
+// neutralizeKind records the listed kind on sig and zeroes its score if the
+// kind is one of NeutralKinds.
+func (pd *PatternDetector) neutralizeKind(sig models.Signal, kind string) models.Signal {
+	sig.ObjectKind = kind
+	for _, k := range pd.NeutralKinds {
+		if k == kind && sig.Score != 0 {
+			sig.Score = 0
+			sig.Description += fmt.Sprintf(" (neutral kind %s)", kind)
+			break
+		}
+	}
+	return sig
+}
+
 // analyzeListCall determines if List is scoped or unscoped.
 func (pd *PatternDetector) analyzeListCall(call *ast.CallExpr) models.Signal {
 	line := pd.fset.Position(call.Pos()).Line
@@ -121,90 +1138,617 @@ func (pd *PatternDetector) analyzeListCall(call *ast.CallExpr) models.Signal {
 	hasReqScopedOpts := false
 	hasNamespaceOpt := false
 	hasLabelOpt := false
+	allNamespaces := false
+	fixedNamespace := ""
+	configNamespace := ""
+	indexedField := ""
 
 	for _, arg := range call.Args[2:] { // skip ctx and list
+		if key := pd.indexedFieldMatch(arg); key != "" {
+			indexedField = key
+			hasReqScopedOpts = true
+			hasLabelOpt = true
+		}
 		if pd.referencesReqParam(arg) {
 			hasReqScopedOpts = true
 		}
 		// Check for specific option types.
 		if pd.isNamespaceOption(arg) {
 			hasNamespaceOpt = true
+			if opt := arg.(*ast.CallExpr); len(opt.Args) == 1 {
+				if pd.isAllNamespaces(opt.Args[0]) {
+					allNamespaces = true
+				} else if ns, ok := pd.constString(opt.Args[0]); ok {
+					fixedNamespace = ns
+				} else if pd.isConfigValue(opt.Args[0]) {
+					configNamespace = types.ExprString(opt.Args[0])
+				}
+			}
 		}
 		if pd.isLabelMatchOption(arg) {
 			hasLabelOpt = true
 		}
+		// Check manually constructed &client.ListOptions{...}.
+		if lit := listOptionsLiteral(arg); lit != nil {
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if key.Name == "Namespace" {
+					if pd.isAllNamespaces(kv.Value) {
+						allNamespaces = true
+					} else if ns, ok := pd.constString(kv.Value); ok {
+						fixedNamespace = ns
+					} else if pd.isConfigValue(kv.Value) {
+						configNamespace = types.ExprString(kv.Value)
+					}
+				}
+				if !pd.derivesFromReq(kv.Value) {
+					continue
+				}
+				switch key.Name {
+				case "Namespace":
+					hasReqScopedOpts = true
+					hasNamespaceOpt = true
+				case "LabelSelector", "FieldSelector":
+					hasReqScopedOpts = true
+					hasLabelOpt = true
+				}
+			}
+		}
+	}
+
+	if allNamespaces {
+		return models.Signal{
+			Type:        models.SignalListUnscoped,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalListUnscoped),
+			Snippet:     snippet,
+			Description: "client.List explicitly across all namespaces",
+		}
+	}
+
+	if fixedNamespace != "" && !hasReqScopedOpts {
+		return models.Signal{
+			Type:        models.SignalCrossNamespaceList,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalCrossNamespaceList),
+			Snippet:     snippet,
+			Description: fmt.Sprintf("client.List in hardcoded namespace %q", fixedNamespace),
+		}
+	}
+
+	if configNamespace != "" && !hasReqScopedOpts {
+		return models.Signal{
+			Type:        models.SignalListConfigNamespace,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalListConfigNamespace),
+			Snippet:     snippet,
+			Description: fmt.Sprintf("client.List in configured namespace %s (not request-scoped)", configNamespace),
+		}
 	}
 
 	if !hasReqScopedOpts {
 		return models.Signal{
 			Type:        models.SignalListUnscoped,
 			Line:        line,
-			Score:       3,
+			Score:       models.DefaultScore(models.SignalListUnscoped),
 			Snippet:     snippet,
 			Description: "client.List without request-scoped selectors",
 		}
 	}
 
-	if hasNamespaceOpt && !hasLabelOpt {
-		return models.Signal{
-			Type:        models.SignalListNamespaceScoped,
-			Line:        line,
-			Score:       1,
-			Snippet:     snippet,
-			Description: "client.List scoped to request namespace only",
+	if hasNamespaceOpt && !hasLabelOpt {
+		return models.Signal{
+			Type:        models.SignalListNamespaceScoped,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalListNamespaceScoped),
+			Snippet:     snippet,
+			Description: "client.List scoped to request namespace only",
+		}
+	}
+
+	if indexedField != "" {
+		return models.Signal{
+			Type:        models.SignalListFieldIndexed,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalListFieldIndexed),
+			Snippet:     snippet,
+			Description: fmt.Sprintf("client.List by field index %q registered in setup, matched against request", indexedField),
+		}
+	}
+
+	return models.Signal{
+		Type:        models.SignalListLabelScoped,
+		Line:        line,
+		Score:       models.DefaultScore(models.SignalListLabelScoped),
+		Snippet:     snippet,
+		Description: "client.List scoped by labels/fields derived from request",
+	}
+}
+
+// analyzeDeleteAllOfCall scores a bulk DeleteAllOf(ctx, obj, opts...) call.
+// It takes the same options as List, so its scope is judged the same way:
+// only a namespace or selectors derived from the request keep it request-scoped.
+// A hardcoded or configured namespace bounds the delete as it bounds a List,
+// so it scores as the List would.
+func (pd *PatternDetector) analyzeDeleteAllOfCall(call *ast.CallExpr) models.Signal {
+	scope := pd.analyzeListCall(call)
+	if scope.Type == "" {
+		return models.Signal{} // malformed
+	}
+
+	desc := "client.DeleteAllOf " + strings.TrimPrefix(scope.Description, "client.List ")
+	switch scope.Type {
+	case models.SignalListNamespaceScoped, models.SignalListLabelScoped, models.SignalListFieldIndexed:
+		return models.Signal{
+			Type:        models.SignalDeleteAllOfScoped,
+			Line:        scope.Line,
+			Score:       models.DefaultScore(models.SignalDeleteAllOfScoped),
+			Snippet:     scope.Snippet,
+			Description: desc,
+		}
+	case models.SignalCrossNamespaceList, models.SignalListConfigNamespace:
+		return models.Signal{
+			Type:        models.SignalDeleteAllOf,
+			Line:        scope.Line,
+			Score:       scope.Score,
+			Snippet:     scope.Snippet,
+			Description: desc,
+		}
+	}
+	return models.Signal{
+		Type:        models.SignalDeleteAllOf,
+		Line:        scope.Line,
+		Score:       models.DefaultScore(models.SignalDeleteAllOf),
+		Snippet:     scope.Snippet,
+		Description: desc,
+	}
+}
+
+// analyzeListerList scores a client-go lister List(selector) call, e.g.
+// r.podLister.List(labels.Everything()) or
+// r.podLister.Pods(req.Namespace).List(selector), in the list_* family.
+// labels.Nothing() selects no objects and is not scored.
+func (pd *PatternDetector) analyzeListerList(call *ast.CallExpr, sel *ast.SelectorExpr) models.Signal {
+	line := pd.fset.Position(call.Pos()).Line
+	snippet := pd.extractSnippet(call)
+	selector := call.Args[0]
+	kind := pd.listerKind(sel.X)
+
+	if pd.isLabelsFunc(selector, "Nothing") {
+		return models.Signal{}
+	}
+
+	if pd.derivesFromReq(selector) {
+		return pd.neutralizeKind(models.Signal{
+			Type:        models.SignalListLabelScoped,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalListLabelScoped),
+			Snippet:     snippet,
+			Description: "Lister List with a selector derived from request",
+		}, kind)
+	}
+
+	// A namespace lister, as in podLister.Pods(req.Namespace).
+	if ns, ok := sel.X.(*ast.CallExpr); ok && len(ns.Args) == 1 && pd.derivesFromReq(ns.Args[0]) {
+		return pd.neutralizeKind(models.Signal{
+			Type:        models.SignalListNamespaceScoped,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalListNamespaceScoped),
+			Snippet:     snippet,
+			Description: "Lister List scoped to request namespace only",
+		}, kind)
+	}
+
+	desc := "Lister List without request-scoped selectors (cache snapshot)"
+	if pd.isLabelsFunc(selector, "Everything") {
+		desc = "Lister List of labels.Everything() (snapshot of the whole cache)"
+	}
+	return pd.neutralizeKind(models.Signal{
+		Type:        models.SignalListUnscoped,
+		Line:        line,
+		Score:       models.DefaultScore(models.SignalListUnscoped),
+		Snippet:     snippet,
+		Description: desc,
+	}, kind)
+}
+
+// isLabelSelector checks if expr is a labels.Selector, such as
+// labels.Everything() or labels.SelectorFromSet(set). Without type info it
+// falls back to calls into the labels package.
+func (pd *PatternDetector) isLabelSelector(expr ast.Expr) bool {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(expr); t != nil && t != types.Typ[types.Invalid] {
+			if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil {
+				return named.Obj().Pkg().Path() == labelsPkgPath && named.Obj().Name() == "Selector"
+			}
+			return false
+		}
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && pd.isPkgSelector(sel, labelsPkgPath, "labels")
+}
+
+// isLabelsFunc checks if expr is a call of the named labels package function.
+func (pd *PatternDetector) isLabelsFunc(expr ast.Expr, name string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == name && pd.isPkgSelector(sel, labelsPkgPath, "labels")
+}
+
+// isLister checks if expr is a client-go lister, such as a PodLister or the
+// PodNamespaceLister returned by podLister.Pods(ns).
+func (pd *PatternDetector) isLister(expr ast.Expr) bool {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(expr); t != nil {
+			return strings.HasSuffix(t.String(), "Lister")
+		}
+	}
+
+	var name string
+	switch x := expr.(type) {
+	case *ast.Ident:
+		name = x.Name
+	case *ast.SelectorExpr:
+		name = x.Sel.Name
+	case *ast.CallExpr:
+		// podLister.Pods(ns)
+		if fn, ok := x.Fun.(*ast.SelectorExpr); ok {
+			return pd.isLister(fn.X)
+		}
+	}
+	return strings.Contains(strings.ToLower(name), "lister")
+}
+
+// listerKind returns the kind served by a lister, e.g. "Pod" for a
+// PodLister or PodNamespaceLister, or "" if it is unknown.
+func (pd *PatternDetector) listerKind(expr ast.Expr) string {
+	if pd.pkg == nil || pd.pkg.TypesInfo == nil {
+		return ""
+	}
+	t := pd.pkg.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return ""
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return ""
+	}
+	name := strings.TrimSuffix(named.Obj().Name(), "Lister")
+	return strings.TrimSuffix(name, "Namespace")
+}
+
+// analyzeListPagination detects List calls that page through results with a
+// Continue token. A Limit without Continue is a bounded single page and is
+// not flagged.
+func (pd *PatternDetector) analyzeListPagination(call *ast.CallExpr) models.Signal {
+	if len(call.Args) < 2 {
+		return models.Signal{}
+	}
+
+	hasContinue := false
+	for _, arg := range call.Args[2:] {
+		if c, ok := arg.(*ast.CallExpr); ok {
+			switch f := c.Fun.(type) {
+			case *ast.Ident:
+				hasContinue = hasContinue || f.Name == "Continue"
+			case *ast.SelectorExpr:
+				hasContinue = hasContinue || f.Sel.Name == "Continue"
+			}
+		}
+		if lit := listOptionsLiteral(arg); lit != nil {
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Continue" {
+						hasContinue = true
+					}
+				}
+			}
+		}
+	}
+
+	if !hasContinue {
+		return models.Signal{}
+	}
+
+	return models.Signal{
+		Type:        models.SignalListPaginated,
+		Line:        pd.fset.Position(call.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalListPaginated),
+		Snippet:     pd.extractSnippet(call),
+		Description: "client.List paginated with a Continue token (full enumeration)",
+	}
+}
+
+// analyzeGetCall determines if Get is req-scoped or not.
+func (pd *PatternDetector) analyzeGetCall(call *ast.CallExpr) models.Signal {
+	line := pd.fset.Position(call.Pos()).Line
+	snippet := pd.extractSnippet(call)
+
+	// Get signature: Get(ctx, key, obj, opts...).
+	if len(call.Args) < 3 {
+		return models.Signal{} // malformed
+	}
+
+	keyArg := call.Args[1]
+
+	// Check if key is exactly req.NamespacedName.
+	if pd.isReqNamespacedName(keyArg) {
+		return models.Signal{
+			Type:        models.SignalGetReqScoped,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalGetReqScoped),
+			Snippet:     snippet,
+			Description: "client.Get with req.NamespacedName (primary resource fetch)",
+		}
+	}
+
+	// Check if key is derived from req.
+	if pd.referencesReqParam(keyArg) {
+		return models.Signal{
+			Type:        models.SignalGetDerived,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalGetDerived),
+			Snippet:     snippet,
+			Description: "client.Get with key derived from request",
+		}
+	}
+
+	// Check if key names a fixed singleton, e.g. the cluster-wide config.
+	if name, ok := pd.constObjectKey(keyArg); ok {
+		return models.Signal{
+			Type:        models.SignalGetSingleton,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalGetSingleton),
+			Snippet:     snippet,
+			Description: fmt.Sprintf("client.Get of singleton %s (config read)", name),
+		}
+	}
+
+	// Key not related to request.
+	return models.Signal{
+		Type:        models.SignalGetUnrelated,
+		Line:        line,
+		Score:       models.DefaultScore(models.SignalGetUnrelated),
+		Snippet:     snippet,
+		Description: "client.Get with key not derived from request",
+	}
+}
+
+// constObjectKey checks if expr is a NamespacedName or ObjectKey literal whose
+// fields are all constant strings, as in types.NamespacedName{Name: "cluster"},
+// and returns the key as "namespace/name" (or "name" if cluster-scoped).
+func (pd *PatternDetector) constObjectKey(expr ast.Expr) (string, bool) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || lit.Type == nil {
+		return "", false
+	}
+	typeName := types.ExprString(lit.Type)
+	if !strings.HasSuffix(typeName, "NamespacedName") && !strings.HasSuffix(typeName, "ObjectKey") {
+		return "", false
+	}
+
+	var name, namespace string
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return "", false
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		value, ok := pd.constString(kv.Value)
+		if !ok {
+			return "", false
+		}
+		switch key.Name {
+		case "Name":
+			name = value
+		case "Namespace":
+			namespace = value
+		}
+	}
+	if name == "" {
+		return "", false
+	}
+	if namespace == "" {
+		return name, true
+	}
+	return namespace + "/" + name, true
+}
+
+const (
+	waitPkgPath           = "k8s.io/apimachinery/pkg/util/wait"
+	metaPkgPath           = "k8s.io/apimachinery/pkg/api/meta"
+	unstructuredPkgPath   = "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientPkgPath         = "sigs.k8s.io/controller-runtime/pkg/client"
+	runtimePkgPath        = "k8s.io/apimachinery/pkg/runtime"
+	labelsPkgPath         = "k8s.io/apimachinery/pkg/labels"
+	retryPkgPath          = "k8s.io/client-go/util/retry"
+	ctrlPkgPath           = "sigs.k8s.io/controller-runtime"
+	controllerutilPkgPath = "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// maxReadinessWait is the longest bounded poll still considered a short readiness wait.
+const maxReadinessWait = time.Minute
+
+// waitTimeoutArg maps bounded wait functions to the index of their timeout argument.
+var waitTimeoutArg = map[string]int{
+	"Poll":                     1,
+	"PollImmediate":            1,
+	"PollWithContext":          2,
+	"PollImmediateWithContext": 2,
+	"PollUntilContextTimeout":  2,
+}
+
+// waitUnbounded lists wait functions that loop until cancelled.
+var waitUnbounded = map[string]bool{
+	"Until":                            true,
+	"UntilWithContext":                 true,
+	"JitterUntil":                      true,
+	"JitterUntilWithContext":           true,
+	"NonSlidingUntil":                  true,
+	"NonSlidingUntilWithContext":       true,
+	"Forever":                          true,
+	"PollInfinite":                     true,
+	"PollImmediateInfinite":            true,
+	"PollInfiniteWithContext":          true,
+	"PollImmediateInfiniteWithContext": true,
+	"PollUntil":                        true,
+	"PollImmediateUntil":               true,
+	"PollUntilWithContext":             true,
+	"PollImmediateUntilWithContext":    true,
+	"PollUntilContextCancel":           true,
+}
+
+// analyzeWaitCall classifies a wait package call as a short readiness wait or a polling loop.
+// A poll counts as a short wait only when its timeout is a constant of at most maxReadinessWait.
+func (pd *PatternDetector) analyzeWaitCall(call *ast.CallExpr, method string) models.Signal {
+	line := pd.fset.Position(call.Pos()).Line
+	snippet := pd.extractSnippet(call)
+
+	if idx, ok := waitTimeoutArg[method]; ok {
+		if idx < len(call.Args) {
+			if d, ok := pd.constDuration(call.Args[idx]); ok && d <= maxReadinessWait {
+				return models.Signal{
+					Type:        models.SignalPollWait,
+					Line:        line,
+					Score:       models.DefaultScore(models.SignalPollWait),
+					Snippet:     snippet,
+					Description: fmt.Sprintf("wait.%s with short timeout (%s)", method, d),
+				}
+			}
 		}
+	} else if !waitUnbounded[method] {
+		return models.Signal{}
 	}
 
 	return models.Signal{
-		Type:        models.SignalListLabelScoped,
+		Type:        models.SignalPollLoop,
 		Line:        line,
-		Score:       0,
+		Score:       models.DefaultScore(models.SignalPollLoop),
 		Snippet:     snippet,
-		Description: "client.List scoped by labels/fields derived from request",
+		Description: fmt.Sprintf("wait.%s polling loop inside Reconcile (SoTW pattern)", method),
 	}
 }
 
-// analyzeGetCall determines if Get is req-scoped or not.
-func (pd *PatternDetector) analyzeGetCall(call *ast.CallExpr) models.Signal {
-	line := pd.fset.Position(call.Pos()).Line
-	snippet := pd.extractSnippet(call)
+// isWorkqueueAdd checks for q.Add/AddRateLimited/AddAfter on a workqueue,
+// identified by its type (via TypesInfo) or by a "queue" receiver name.
+func (pd *PatternDetector) isWorkqueueAdd(sel *ast.SelectorExpr) bool {
+	switch sel.Sel.Name {
+	case "Add", "AddRateLimited", "AddAfter":
+	default:
+		return false
+	}
 
-	// Get signature: Get(ctx, key, obj, opts...).
-	if len(call.Args) < 3 {
-		return models.Signal{} // malformed
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(sel.X); t != nil && strings.Contains(t.String(), "workqueue.") {
+			return true
+		}
 	}
 
-	keyArg := call.Args[1]
+	var name string
+	switch x := sel.X.(type) {
+	case *ast.Ident:
+		name = x.Name
+	case *ast.SelectorExpr:
+		name = x.Sel.Name
+	}
+	return strings.Contains(strings.ToLower(name), "queue")
+}
 
-	// Check if key is exactly req.NamespacedName.
-	if pd.isReqNamespacedName(keyArg) {
-		return models.Signal{
-			Type:        models.SignalGetReqScoped,
-			Line:        line,
-			Score:       -1,
-			Snippet:     snippet,
-			Description: "client.Get with req.NamespacedName (primary resource fetch)",
+// isTrackerWatch checks if sel is a Watch call on an object tracker, such as
+// r.externalTracker.Watch(log, obj, handler) with cluster-api's
+// external.ObjectTracker.
+func (pd *PatternDetector) isTrackerWatch(sel *ast.SelectorExpr) bool {
+	if sel.Sel.Name != "Watch" {
+		return false
+	}
+
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(sel.X); t != nil {
+			return strings.HasSuffix(t.String(), "Tracker")
 		}
 	}
 
-	// Check if key is derived from req.
-	if pd.referencesReqParam(keyArg) {
-		return models.Signal{
-			Type:        models.SignalGetDerived,
-			Line:        line,
-			Score:       -1,
-			Snippet:     snippet,
-			Description: "client.Get with key derived from request",
+	var name string
+	switch x := sel.X.(type) {
+	case *ast.Ident:
+		name = x.Name
+	case *ast.SelectorExpr:
+		name = x.Sel.Name
+	}
+	return strings.Contains(strings.ToLower(name), "tracker")
+}
+
+// isGVKLookup checks if sel is a runtime kind lookup: RESTMapper.RESTMapping
+// or RESTMappings, or Scheme.ObjectKinds. Without type info the method name
+// alone is matched.
+func (pd *PatternDetector) isGVKLookup(sel *ast.SelectorExpr) bool {
+	var pkgPath string
+	switch sel.Sel.Name {
+	case "RESTMapping", "RESTMappings":
+		pkgPath = metaPkgPath
+	case "ObjectKinds":
+		pkgPath = runtimePkgPath
+	default:
+		return false
+	}
+
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if obj := pd.pkg.TypesInfo.Uses[sel.Sel]; obj != nil {
+			return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath
 		}
+		pd.warnf("line %d: unresolved method %s matched by name", pd.fset.Position(sel.Pos()).Line, sel.Sel.Name)
+	}
+	return true
+}
+
+// analyzeSendStmt detects reconcile requests or generic events sent on a
+// channel from inside Reconcile, which enqueues other objects.
+func (pd *PatternDetector) analyzeSendStmt(send *ast.SendStmt) models.Signal {
+	var typeName string
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(send.Value); t != nil {
+			typeName = t.String()
+		}
+	}
+	if typeName == "" {
+		if lit, ok := send.Value.(*ast.CompositeLit); ok && lit.Type != nil {
+			typeName = types.ExprString(lit.Type)
+		}
+	}
+
+	if !strings.HasSuffix(typeName, "Request") && !strings.HasSuffix(typeName, "GenericEvent") {
+		return models.Signal{}
 	}
 
-	// Key not related to request.
 	return models.Signal{
-		Type:        models.SignalGetUnrelated,
-		Line:        line,
-		Score:       1,
-		Snippet:     snippet,
-		Description: "client.Get with key not derived from request",
+		Type:        models.SignalManualEnqueue,
+		Line:        pd.fset.Position(send.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalManualEnqueue),
+		Snippet:     pd.extractSnippet(send),
+		Description: "Request/event sent on a channel from Reconcile (fan-out to other objects)",
 	}
 }
 
@@ -213,29 +1757,192 @@ func (pd *PatternDetector) analyzeWriteCall(call *ast.CallExpr, method string) m
 	line := pd.fset.Position(call.Pos()).Line
 	snippet := pd.extractSnippet(call)
 
+	// Writes through the status subresource only report observed state.
+	if sel := call.Fun.(*ast.SelectorExpr); isStatusSubresource(sel) && (method == "Update" || method == "Patch") {
+		sig := models.Signal{
+			Type:        models.SignalStatusUpdate,
+			Line:        line,
+			Score:       models.DefaultScore(models.SignalStatusUpdate),
+			Snippet:     snippet,
+			Description: fmt.Sprintf("client.Status().%s call (status subresource)", method),
+		}
+		if method == "Patch" && len(call.Args) >= 3 {
+			sig.FieldManager = pd.fieldManager(call.Args[3:])
+		}
+		return sig
+	}
+
+	// Patch signature: Patch(ctx, obj, patch, opts...).
+	if method == "Patch" && len(call.Args) >= 3 {
+		fieldManager := pd.fieldManager(call.Args[3:])
+		if pd.isClientApply(call.Args[2]) {
+			desc := "client.Patch with client.Apply (server-side apply)"
+			if fieldManager != "" {
+				desc = fmt.Sprintf("client.Patch with client.Apply (server-side apply as %q)", fieldManager)
+			}
+			kind, declarative := pd.applyConfigurationKind(call.Args[1])
+			if declarative {
+				desc += fmt.Sprintf(" of a %sApplyConfiguration", kind)
+			}
+			return models.Signal{
+				Type:         models.SignalServerSideApply,
+				Line:         line,
+				Score:        models.DefaultScore(models.SignalServerSideApply),
+				Snippet:      snippet,
+				Description:  desc,
+				FieldManager: fieldManager,
+				ObjectKind:   kind,
+			}
+		}
+		return models.Signal{
+			Type:         models.SignalSingleWrite,
+			Line:         line,
+			Score:        models.DefaultScore(models.SignalSingleWrite),
+			Snippet:      snippet,
+			Description:  "client.Patch call",
+			FieldManager: fieldManager,
+		}
+	}
+
 	return models.Signal{
 		Type:        models.SignalSingleWrite,
 		Line:        line,
-		Score:       -1,
+		Score:       models.DefaultScore(models.SignalSingleWrite),
 		Snippet:     snippet,
 		Description: fmt.Sprintf("client.%s call", method),
 	}
 }
 
+// applyConfigurationKind reports whether expr is a generated apply
+// configuration, e.g. a *DeploymentApplyConfiguration built with
+// appsv1ac.Deployment(name, ns).WithSpec(...), and returns its kind. Without
+// type info it falls back to the name of a composite literal's type.
+func (pd *PatternDetector) applyConfigurationKind(expr ast.Expr) (string, bool) {
+	const suffix = "ApplyConfiguration"
+	var name string
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(expr); t != nil {
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if named, ok := types.Unalias(t).(*types.Named); ok {
+				name = named.Obj().Name()
+			}
+		}
+	}
+	if name == "" {
+		if unary, ok := expr.(*ast.UnaryExpr); ok {
+			expr = unary.X
+		}
+		if lit, ok := expr.(*ast.CompositeLit); ok && lit.Type != nil {
+			name = types.ExprString(lit.Type)
+			name = name[strings.LastIndex(name, ".")+1:]
+		}
+	}
+	if !strings.HasSuffix(name, suffix) || name == suffix {
+		return "", false
+	}
+	return strings.TrimSuffix(name, suffix), true
+}
+
+// isClientApply checks if expr is the client.Apply patch type.
+func (pd *PatternDetector) isClientApply(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Apply" && pd.isPkgSelector(sel, clientPkgPath, "client")
+}
+
+// fieldManager returns the field manager named by client.FieldOwner(...) or
+// a &client.PatchOptions{FieldManager: ...} literal among Patch options.
+func (pd *PatternDetector) fieldManager(opts []ast.Expr) string {
+	for _, opt := range opts {
+		if call, ok := opt.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "FieldOwner" &&
+				pd.isPkgSelector(sel, clientPkgPath, "client") {
+				return pd.stringValue(call.Args[0])
+			}
+		}
+		unary, ok := opt.(*ast.UnaryExpr)
+		if !ok || unary.Op != token.AND {
+			continue
+		}
+		lit, ok := unary.X.(*ast.CompositeLit)
+		if !ok || !selectsField(lit.Type, "PatchOptions") {
+			continue
+		}
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "FieldManager" {
+					return pd.stringValue(kv.Value)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// stringValue returns the constant string value of expr, or its source text
+// when it is not a constant.
+func (pd *PatternDetector) stringValue(expr ast.Expr) string {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if tv, ok := pd.pkg.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value)
+		}
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if s, err := strconv.Unquote(lit.Value); err == nil {
+			return s
+		}
+	}
+	return types.ExprString(expr)
+}
+
 // detectControlFlowPatterns detects early return on NotFound, etc.
 func (pd *PatternDetector) detectControlFlowPatterns(ifStmt *ast.IfStmt) []models.Signal {
 	var signals []models.Signal
 
+	if pd.upsertIfs[ifStmt] {
+		signals = append(signals, models.Signal{
+			Type:        models.SignalCreateOrUpdate,
+			Line:        pd.fset.Position(ifStmt.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalCreateOrUpdate),
+			Snippet:     pd.extractSnippet(ifStmt),
+			Description: "Get, then Create on NotFound or Update otherwise (manual create-or-update)",
+		})
+		return signals
+	}
+
+	// Check for: if obj.Annotations["...paused"] ... { return }.
+	if key := pd.pauseAnnotation(ifStmt); key != "" && pd.isEarlyReturn(ifStmt.Body) {
+		pd.respectsPause = true
+		signals = append(signals, models.Signal{
+			Type:        models.SignalPauseCheck,
+			Line:        pd.fset.Position(ifStmt.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalPauseCheck),
+			Snippet:     pd.extractSnippet(ifStmt),
+			Description: fmt.Sprintf("Early return while paused via %s", key),
+		})
+		return signals
+	}
+
 	// Check for: if apierrors.IsNotFound(err) { ... }.
 	if pd.isNotFoundCheck(ifStmt.Cond) {
 		// Check what happens in the body.
-		if pd.isEarlyReturn(ifStmt.Body) {
+		if pd.hasClientCall(ifStmt.Body, "Create") {
+			// The missing object is recreated rather than treated as a delete.
+			signals = append(signals, models.Signal{
+				Type:        models.SignalCreateOnMissing,
+				Line:        pd.fset.Position(ifStmt.Pos()).Line,
+				Score:       models.DefaultScore(models.SignalCreateOnMissing),
+				Snippet:     pd.extractSnippet(ifStmt),
+				Description: "NotFound handling creates the missing object (reconcile-to-exist)",
+			})
+		} else if pd.isEarlyReturn(ifStmt.Body) {
 			// Check if it just returns nil or handles delete.
 			if pd.isNilReturn(ifStmt.Body) {
 				signals = append(signals, models.Signal{
 					Type:        models.SignalNotFoundIgnore,
 					Line:        pd.fset.Position(ifStmt.Pos()).Line,
-					Score:       -1,
+					Score:       models.DefaultScore(models.SignalNotFoundIgnore),
 					Snippet:     pd.extractSnippet(ifStmt),
 					Description: "Early return on NotFound (ignores deletes)",
 				})
@@ -243,7 +1950,7 @@ func (pd *PatternDetector) detectControlFlowPatterns(ifStmt *ast.IfStmt) []model
 				signals = append(signals, models.Signal{
 					Type:        models.SignalNotFoundEarlyReturn,
 					Line:        pd.fset.Position(ifStmt.Pos()).Line,
-					Score:       -2,
+					Score:       models.DefaultScore(models.SignalNotFoundEarlyReturn),
 					Snippet:     pd.extractSnippet(ifStmt),
 					Description: "NotFound handling with delete logic (classic edge-triggered pattern)",
 				})
@@ -254,6 +1961,65 @@ func (pd *PatternDetector) detectControlFlowPatterns(ifStmt *ast.IfStmt) []model
 	return signals
 }
 
+// pauseAnnotation returns the paused annotation key read in ifStmt's init or
+// condition, as in obj.Annotations["cluster.x-k8s.io/paused"],
+// obj.GetAnnotations()[key] or metav1.HasAnnotation(obj.ObjectMeta, key), or
+// the name of a pause helper such as annotations.IsPaused. It returns "" if
+// there is none.
+func (pd *PatternDetector) pauseAnnotation(ifStmt *ast.IfStmt) string {
+	var key string
+	check := func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IndexExpr:
+			if isAnnotationMap(node.X) {
+				if s, ok := pd.constString(node.Index); ok && isPauseKey(s) {
+					key = fmt.Sprintf("annotation %q", s)
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch {
+			case sel.Sel.Name == "HasAnnotation" && len(node.Args) == 2:
+				if s, ok := pd.constString(node.Args[1]); ok && isPauseKey(s) {
+					key = fmt.Sprintf("annotation %q", s)
+				}
+			case sel.Sel.Name == "IsPaused" || sel.Sel.Name == "HasPaused":
+				key = types.ExprString(sel)
+			}
+		}
+		return key == ""
+	}
+	if ifStmt.Init != nil {
+		ast.Inspect(ifStmt.Init, check)
+	}
+	if key == "" {
+		ast.Inspect(ifStmt.Cond, check)
+	}
+	return key
+}
+
+// isAnnotationMap checks if expr is x.Annotations or x.GetAnnotations().
+func isAnnotationMap(expr ast.Expr) bool {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 0 {
+		expr = call.Fun
+		sel, ok := expr.(*ast.SelectorExpr)
+		return ok && sel.Sel.Name == "GetAnnotations"
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Annotations"
+}
+
+// isPauseKey checks if an annotation key names a pause switch, e.g.
+// "cluster.x-k8s.io/paused".
+func isPauseKey(key string) bool {
+	return strings.Contains(strings.ToLower(key), "pause")
+}
+
 // detectLoopPatterns detects for loops containing write operations.
 func (pd *PatternDetector) detectLoopPatterns(forStmt *ast.ForStmt) []models.Signal {
 	var signals []models.Signal
@@ -266,7 +2032,7 @@ func (pd *PatternDetector) detectLoopPatterns(forStmt *ast.ForStmt) []models.Sig
 		signals = append(signals, models.Signal{
 			Type:        models.SignalLoopWrite,
 			Line:        pd.fset.Position(forStmt.Pos()).Line,
-			Score:       3,
+			Score:       models.DefaultScore(models.SignalLoopWrite),
 			Snippet:     pd.extractSnippet(forStmt),
 			Description: "Loop containing write operations (SoTW pattern)",
 		})
@@ -283,11 +2049,58 @@ func (pd *PatternDetector) detectRangeLoopPatterns(rangeStmt *ast.RangeStmt) []m
 		return signals
 	}
 
+	if pd.indexesListByName(rangeStmt) {
+		signals = append(signals, models.Signal{
+			Type:        models.SignalListIndexed,
+			Line:        pd.fset.Position(rangeStmt.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalListIndexed),
+			Snippet:     pd.extractSnippet(rangeStmt),
+			Description: "List items indexed into a map by name (diff-then-sync)",
+		})
+	}
+
+	if pd.isRenderedObjects(rangeStmt.X) && pd.hasWriteOperation(rangeStmt.Body) {
+		signals = append(signals, models.Signal{
+			Type:        models.SignalApplyRendered,
+			Line:        pd.fset.Position(rangeStmt.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalApplyRendered),
+			Snippet:     pd.extractSnippet(rangeStmt),
+			Description: "Loop applying a rendered object set (build full desired state, then apply)",
+		})
+		return signals
+	}
+
+	// Status writes over a request-scoped list are fan-out to children and
+	// are left to loop_write_scoped below.
+	if name := rootIdent(rangeStmt.X); name != "" && pd.listed[name] && !pd.scopedLists[name] {
+		if pd.hasStatusWrite(rangeStmt.Body) {
+			signals = append(signals, models.Signal{
+				Type:        models.SignalBulkStatusUpdate,
+				Line:        pd.fset.Position(rangeStmt.Pos()).Line,
+				Score:       models.DefaultScore(models.SignalBulkStatusUpdate),
+				Snippet:     pd.extractSnippet(rangeStmt),
+				Description: "Loop updating the status of every listed item (SoTW status reconciliation)",
+			})
+			return signals
+		}
+	}
+
 	if pd.hasWriteOperation(rangeStmt.Body) {
+		if name := rootIdent(rangeStmt.X); name != "" && pd.scopedLists[name] {
+			signals = append(signals, models.Signal{
+				Type:        models.SignalLoopWriteScoped,
+				Line:        pd.fset.Position(rangeStmt.Pos()).Line,
+				Score:       models.DefaultScore(models.SignalLoopWriteScoped),
+				Snippet:     pd.extractSnippet(rangeStmt),
+				Description: "Loop writing items of a request-scoped list (edge-triggered fan-out to children)",
+			})
+			return signals
+		}
+
 		signals = append(signals, models.Signal{
 			Type:        models.SignalLoopWrite,
 			Line:        pd.fset.Position(rangeStmt.Pos()).Line,
-			Score:       3,
+			Score:       models.DefaultScore(models.SignalLoopWrite),
 			Snippet:     pd.extractSnippet(rangeStmt),
 			Description: "Loop containing write operations (SoTW pattern)",
 		})
@@ -296,10 +2109,249 @@ func (pd *PatternDetector) detectRangeLoopPatterns(rangeStmt *ast.RangeStmt) []m
 	return signals
 }
 
+// isRenderedObjects checks if expr is a slice of generic objects, such as a
+// []client.Object or []runtime.Object returned by a manifest renderer, i.e.
+// of an interface with DeepCopyObject. Without type info it falls back to
+// calls whose name mentions rendering.
+func (pd *PatternDetector) isRenderedObjects(expr ast.Expr) bool {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(expr); t != nil {
+			slice, ok := t.Underlying().(*types.Slice)
+			if !ok {
+				return false
+			}
+			if types.IsInterface(slice.Elem()) {
+				if obj, _, _ := types.LookupFieldOrMethod(slice.Elem(), false, nil, "DeepCopyObject"); obj != nil {
+					return true
+				}
+			}
+			named, ok := types.Unalias(slice.Elem()).(*types.Named)
+			if !ok || named.Obj().Pkg() == nil || named.Obj().Name() != "Object" {
+				return false
+			}
+			switch named.Obj().Pkg().Path() {
+			case clientPkgPath, runtimePkgPath:
+				return true
+			}
+			return false
+		}
+	}
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	var name string
+	switch f := call.Fun.(type) {
+	case *ast.Ident:
+		name = f.Name
+	case *ast.SelectorExpr:
+		name = f.Sel.Name
+	}
+	if strings.Contains(strings.ToLower(name), "render") {
+		pd.warnf("line %d: %s matched as a rendered object set by name", pd.fset.Position(call.Pos()).Line, name)
+		return true
+	}
+	return false
+}
+
+// hasUpsertHelper checks if body calls controllerutil.CreateOrUpdate or
+// controllerutil.CreateOrPatch.
+func (pd *PatternDetector) hasUpsertHelper(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && pd.isUpsertHelper(sel) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isUpsertHelper checks if sel is controllerutil.CreateOrUpdate or
+// controllerutil.CreateOrPatch.
+func (pd *PatternDetector) isUpsertHelper(sel *ast.SelectorExpr) bool {
+	return (sel.Sel.Name == "CreateOrUpdate" || sel.Sel.Name == "CreateOrPatch") &&
+		pd.isPkgSelector(sel, controllerutilPkgPath, "controllerutil")
+}
+
+// detectDiffSync reports the desired-vs-existing idiom: the function lists
+// existing objects (unscoped, or the request's children by owner labels),
+// writes in a loop, and prunes listed objects missing from the desired set,
+// i.e. a loop deleting items conditionally. Only the pruning loop is
+// reported, on top of the list and loop signals it composes.
+func (pd *PatternDetector) detectDiffSync(body *ast.BlockStmt, signals []models.Signal) models.Signal {
+	var listed, loopWrite bool
+	for _, sig := range signals {
+		switch sig.Type {
+		case models.SignalListUnscoped, models.SignalListOwnerScoped, models.SignalListLabelScoped, models.SignalListFieldIndexed:
+			listed = true
+		case models.SignalLoopWrite, models.SignalLoopWriteScoped, models.SignalApplyRendered:
+			loopWrite = true
+		}
+	}
+	if !listed || !loopWrite {
+		return models.Signal{}
+	}
+
+	var prune *ast.RangeStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		if prune != nil {
+			return false
+		}
+		if rangeStmt, ok := n.(*ast.RangeStmt); ok && rangeStmt.Body != nil && pd.isPruneLoop(rangeStmt.Body) {
+			prune = rangeStmt
+			return false
+		}
+		return true
+	})
+	if prune == nil {
+		return models.Signal{}
+	}
+
+	return models.Signal{
+		Type:        models.SignalDiffSync,
+		Line:        pd.fset.Position(prune.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalDiffSync),
+		Snippet:     pd.extractSnippet(prune),
+		Description: "Listed objects missing from the desired set are deleted (diff-then-sync)",
+	}
+}
+
+// isPruneLoop checks if a loop body deletes items conditionally, either
+// inside an if statement or after an if statement that skips the item.
+func (pd *PatternDetector) isPruneLoop(body *ast.BlockStmt) bool {
+	skips := false
+	for _, stmt := range body.List {
+		if skips && pd.hasClientCall(&ast.BlockStmt{List: []ast.Stmt{stmt}}, "Delete") {
+			return true
+		}
+		ifStmt, ok := stmt.(*ast.IfStmt)
+		if !ok {
+			continue
+		}
+		if pd.hasClientCall(ifStmt.Body, "Delete") {
+			return true
+		}
+		if n := len(ifStmt.Body.List); n > 0 {
+			if branch, ok := ifStmt.Body.List[n-1].(*ast.BranchStmt); ok && branch.Tok == token.CONTINUE {
+				skips = true
+			}
+		}
+	}
+	return false
+}
+
+// indexesListByName checks if a loop over a List result stores items into a
+// map keyed by their name, e.g. existing[item.Name] = item or
+// byName[list.Items[i].GetName()] = &list.Items[i].
+func (pd *PatternDetector) indexesListByName(rangeStmt *ast.RangeStmt) bool {
+	list := rootIdent(rangeStmt.X)
+	if list == "" || !pd.listed[list] {
+		return false
+	}
+
+	// Names that refer to the current item.
+	items := map[string]bool{list: true}
+	for _, v := range []ast.Expr{rangeStmt.Key, rangeStmt.Value} {
+		if ident, ok := v.(*ast.Ident); ok && ident.Name != "_" {
+			items[ident.Name] = true
+		}
+	}
+
+	for _, stmt := range rangeStmt.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok {
+			continue
+		}
+		for _, lhs := range assign.Lhs {
+			index, ok := lhs.(*ast.IndexExpr)
+			if !ok {
+				continue
+			}
+			if name := objectNameOf(index.Index); name != "" && items[name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// objectNameOf returns the base identifier of an x.Name, x.ObjectMeta.Name or
+// x.GetName() expression, or "" if expr is none of these.
+func objectNameOf(expr ast.Expr) string {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 0 {
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "GetName" {
+			return rootIdent(sel.X)
+		}
+		return ""
+	}
+	if sel, ok := expr.(*ast.SelectorExpr); ok && sel.Sel.Name == "Name" {
+		return rootIdent(sel.X)
+	}
+	return ""
+}
+
+// rootIdent returns the base identifier of expressions like &list,
+// list.Items or list.Items[i], or "" if there is none.
+func rootIdent(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e.Name
+		case *ast.UnaryExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		default:
+			return ""
+		}
+	}
+}
+
+// hasStatusWrite checks if a loop body writes through the status
+// subresource, e.g. r.Status().Update(ctx, &item).
+func (pd *PatternDetector) hasStatusWrite(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !pd.isClientCall(sel) {
+			return true
+		}
+		switch sel.Sel.Name {
+		case "Create", "Update", "Delete", "DeleteAllOf", "Patch":
+			found = isStatusSubresource(sel)
+		}
+		return true
+	})
+	return found
+}
+
 // hasWriteOperation checks if a block contains client write operations.
 func (pd *PatternDetector) hasWriteOperation(body *ast.BlockStmt) bool {
-	hasWrite := false
+	return pd.hasClientCall(body, "Create", "Update", "Delete", "DeleteAllOf", "Patch") || pd.hasUpsertHelper(body)
+}
+
+// hasClientCall checks if a block contains a client call to any of the given methods.
+func (pd *PatternDetector) hasClientCall(body *ast.BlockStmt, methods ...string) bool {
+	found := false
 	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -309,15 +2361,16 @@ func (pd *PatternDetector) hasWriteOperation(body *ast.BlockStmt) bool {
 			return true
 		}
 		if pd.isClientCall(sel) {
-			switch sel.Sel.Name {
-			case "Create", "Update", "Delete", "Patch":
-				hasWrite = true
-				return false
+			for _, m := range methods {
+				if sel.Sel.Name == m {
+					found = true
+					return false
+				}
 			}
 		}
 		return true
 	})
-	return hasWrite
+	return found
 }
 
 // Helper methods.
@@ -329,7 +2382,7 @@ func (pd *PatternDetector) isClientCall(sel *ast.SelectorExpr) bool {
 	methodName := sel.Sel.Name
 	isClientMethod := methodName == "Get" || methodName == "List" ||
 		methodName == "Create" || methodName == "Update" ||
-		methodName == "Delete" || methodName == "Patch"
+		methodName == "Delete" || methodName == "DeleteAllOf" || methodName == "Patch"
 
 	// Check common patterns: r.Client, c.client, Client, client, etc.
 	switch x := sel.X.(type) {
@@ -357,6 +2410,43 @@ func (pd *PatternDetector) isClientCall(sel *ast.SelectorExpr) bool {
 	return false
 }
 
+// isPkgSelector checks if a selector refers to a member of the package with the given import path.
+// Without type info, it falls back to comparing the package identifier against fallbackName.
+func (pd *PatternDetector) isPkgSelector(sel *ast.SelectorExpr, pkgPath, fallbackName string) bool {
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if pkgName, ok := pd.pkg.TypesInfo.Uses[ident].(*types.PkgName); ok {
+			return pkgName.Imported().Path() == pkgPath
+		}
+		if pd.pkg.TypesInfo.Uses[ident] != nil {
+			return false
+		}
+		if ident.Name == fallbackName {
+			pd.warnf("line %d: unresolved identifier %s matched %s by name", pd.fset.Position(ident.Pos()).Line, ident.Name, pkgPath)
+		}
+	}
+	return ident.Name == fallbackName
+}
+
+// constDuration evaluates expr as a constant time.Duration if possible.
+func (pd *PatternDetector) constDuration(expr ast.Expr) (time.Duration, bool) {
+	if pd.pkg == nil || pd.pkg.TypesInfo == nil {
+		return 0, false
+	}
+	tv, ok := pd.pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return 0, false
+	}
+	ns, exact := constant.Int64Val(tv.Value)
+	if !exact {
+		return 0, false
+	}
+	return time.Duration(ns), true
+}
+
 // isClientIdentifier checks if a name looks like a client identifier.
 func (pd *PatternDetector) isClientIdentifier(name string) bool {
 	for _, candidate := range pd.clientFieldNames {
@@ -384,6 +2474,432 @@ func (pd *PatternDetector) referencesReqParam(expr ast.Expr) bool {
 	return found
 }
 
+// collectReqDerived records local variables assigned from expressions that
+// reference the request parameter (or another request-derived local).
+func (pd *PatternDetector) collectReqDerived(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Rhs) == 0 {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				// Errors, as in x, err := f(req), do not carry the request.
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || pd.isErrorValue(ident) {
+					continue
+				}
+				// Multi-value assignments share one RHS.
+				rhs := node.Rhs[0]
+				if len(node.Rhs) == len(node.Lhs) {
+					rhs = node.Rhs[i]
+				}
+				if pd.derivesFromReq(rhs) {
+					pd.reqDerived[ident.Name] = true
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if len(node.Values) == 0 || pd.isErrorValue(name) {
+					continue
+				}
+				value := node.Values[0]
+				if len(node.Values) == len(node.Names) {
+					value = node.Values[i]
+				}
+				if pd.derivesFromReq(value) {
+					pd.reqDerived[name.Name] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+// isErrorValue checks if ident is an error variable: typed error, or named
+// err or ...Err without type information.
+func (pd *PatternDetector) isErrorValue(ident *ast.Ident) bool {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if obj := pd.pkg.TypesInfo.ObjectOf(ident); obj != nil && obj.Type() != nil {
+			return types.Identical(obj.Type(), types.Universe.Lookup("error").Type())
+		}
+	}
+	return ident.Name == "err" || strings.HasSuffix(ident.Name, "Err")
+}
+
+// collectUpserts finds manual get-or-create sequences: a Get keyed from the
+// request, followed by if IsNotFound { Create } else { Update } (or Patch) on
+// the same object. Each such sequence is one logical upsert.
+func (pd *PatternDetector) collectUpserts(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			pd.collectMutateWrites(call)
+			return true
+		}
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		for i, stmt := range block.List {
+			var get *ast.CallExpr
+			var ifStmt *ast.IfStmt
+			switch st := stmt.(type) {
+			case *ast.IfStmt:
+				// if err := r.Get(...); IsNotFound(err) { ... }
+				if st.Init != nil {
+					get, ifStmt = pd.getCall(st.Init), st
+				}
+			case *ast.AssignStmt:
+				// err := r.Get(...) followed by the if statement.
+				if i+1 < len(block.List) {
+					get = pd.getCall(st)
+					ifStmt, _ = block.List[i+1].(*ast.IfStmt)
+				}
+			}
+			if get == nil || ifStmt == nil || ifStmt.Else == nil || !pd.isNotFoundCheck(ifStmt.Cond) {
+				continue
+			}
+			if !pd.derivesFromReq(get.Args[1]) {
+				continue
+			}
+
+			obj := rootIdent(get.Args[2])
+			if obj == "" {
+				continue
+			}
+			create := pd.findObjectCall(ifStmt.Body, obj, "Create")
+			update := pd.findObjectCall(ifStmt.Else, obj, "Update", "Patch")
+			if create == nil || update == nil {
+				continue
+			}
+
+			pd.upsertIfs[ifStmt] = true
+			for _, call := range []*ast.CallExpr{get, create, update} {
+				pd.upsertCalls[call.Pos()] = true
+			}
+		}
+		return true
+	})
+}
+
+// collectMutateWrites marks client writes of the upserted object inside the
+// mutate function of a controllerutil.CreateOrUpdate/CreateOrPatch call
+// (ctx, client, obj, mutate) as part of the upsert, so they are not counted
+// again as separate writes.
+func (pd *PatternDetector) collectMutateWrites(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !pd.isUpsertHelper(sel) || len(call.Args) < 4 {
+		return
+	}
+	mutate, ok := call.Args[3].(*ast.FuncLit)
+	obj := rootIdent(call.Args[2])
+	if !ok || obj == "" {
+		return
+	}
+
+	ast.Inspect(mutate.Body, func(n ast.Node) bool {
+		write, ok := n.(*ast.CallExpr)
+		if !ok || len(write.Args) < 2 || rootIdent(write.Args[1]) != obj {
+			return true
+		}
+		ws, ok := write.Fun.(*ast.SelectorExpr)
+		if !ok || !pd.isClientCall(ws) {
+			return true
+		}
+		// Status().Update and other subresource writes are not part of the upsert.
+		if _, sub := ws.X.(*ast.CallExpr); sub {
+			return true
+		}
+		switch ws.Sel.Name {
+		case "Create", "Update", "Patch":
+			pd.upsertCalls[write.Pos()] = true
+		}
+		return true
+	})
+}
+
+// detectGetMutateUpdate reports the textbook edge-triggered sequence on one
+// object: Get it with a request-derived key, mutate it in place, then Update
+// or Patch it. The signal is emitted once, at the first such write.
+func (pd *PatternDetector) detectGetMutateUpdate(body *ast.BlockStmt) models.Signal {
+	var write *ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		if write != nil {
+			return false
+		}
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		for i, stmt := range block.List {
+			get := pd.getCall(stmt)
+			if ifStmt, ok := stmt.(*ast.IfStmt); ok && ifStmt.Init != nil {
+				get = pd.getCall(ifStmt.Init)
+			}
+			if get == nil || pd.upsertCalls[get.Pos()] || !pd.derivesFromReq(get.Args[1]) {
+				continue
+			}
+			obj := rootIdent(get.Args[2])
+			if obj == "" {
+				continue
+			}
+
+			mutated := false
+			for _, next := range block.List[i+1:] {
+				if mutated {
+					if call := pd.findObjectCall(next, obj, "Update", "Patch"); call != nil && !pd.upsertCalls[call.Pos()] {
+						write = call
+						return false
+					}
+				}
+				mutated = mutated || mutatesObject(next, obj)
+			}
+		}
+		return true
+	})
+	if write == nil {
+		return models.Signal{}
+	}
+
+	return models.Signal{
+		Type:        models.SignalGetMutateUpdate,
+		Line:        pd.fset.Position(write.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalGetMutateUpdate),
+		Snippet:     pd.extractSnippet(write),
+		Description: "Object fetched by request key, mutated in place and written back (edge-triggered)",
+	}
+}
+
+// mutatesObject checks if stmt assigns to a field of obj (obj.Spec.X = ...,
+// obj.Labels[k] = ...), or calls one of its setters or a controllerutil
+// finalizer helper on it.
+func mutatesObject(stmt ast.Stmt, obj string) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if _, ok := lhs.(*ast.Ident); !ok && rootIdent(lhs) == obj {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if _, ok := node.X.(*ast.Ident); !ok && rootIdent(node.X) == obj {
+				found = true
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if strings.HasPrefix(sel.Sel.Name, "Set") && rootIdent(sel.X) == obj {
+				found = true
+			}
+			if (sel.Sel.Name == "AddFinalizer" || sel.Sel.Name == "RemoveFinalizer") &&
+				len(node.Args) > 0 && rootIdent(node.Args[0]) == obj {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// analyzeRetryCall emits a conflict retry signal if the function retried by
+// retry.RetryOnConflict or retry.OnError performs a client write.
+func (pd *PatternDetector) analyzeRetryCall(call *ast.CallExpr, method string) models.Signal {
+	if len(call.Args) == 0 {
+		return models.Signal{}
+	}
+	lit, ok := call.Args[len(call.Args)-1].(*ast.FuncLit)
+	if !ok || !pd.hasWriteOperation(lit.Body) {
+		return models.Signal{}
+	}
+
+	return models.Signal{
+		Type:        models.SignalConflictRetry,
+		Line:        pd.fset.Position(call.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalConflictRetry),
+		Snippet:     pd.extractSnippet(call),
+		Description: fmt.Sprintf("retry.%s around a write (optimistic-concurrency retry)", method),
+	}
+}
+
+// isDeepEqual checks for reflect.DeepEqual and the apimachinery
+// equality.Semantic.DeepEqual/DeepDerivative helpers.
+func (pd *PatternDetector) isDeepEqual(sel *ast.SelectorExpr) bool {
+	switch sel.Sel.Name {
+	case "DeepEqual":
+		if pd.isPkgSelector(sel, "reflect", "reflect") {
+			return true
+		}
+	case "DeepDerivative":
+	default:
+		return false
+	}
+	inner, ok := sel.X.(*ast.SelectorExpr)
+	return ok && inner.Sel.Name == "Semantic"
+}
+
+// analyzeDeepEqual emits a drift check signal if a DeepEqual call compares
+// two objects or specs. Comparisons of metadata maps such as labels and
+// annotations, or of scalar fields, are not drift checks and are ignored.
+// Repeated comparisons are reported once per function.
+func (pd *PatternDetector) analyzeDeepEqual(call *ast.CallExpr) models.Signal {
+	if pd.hasDriftCheck || len(call.Args) != 2 {
+		return models.Signal{}
+	}
+	for _, arg := range call.Args {
+		if !pd.isStateOperand(arg) {
+			return models.Signal{}
+		}
+	}
+
+	pd.hasDriftCheck = true
+	return models.Signal{
+		Type:        models.SignalDriftCheck,
+		Line:        pd.fset.Position(call.Pos()).Line,
+		Score:       models.DefaultScore(models.SignalDriftCheck),
+		Snippet:     pd.extractSnippet(call),
+		Description: "DeepEqual of desired and actual state (diff-then-sync)",
+	}
+}
+
+// isStateOperand checks if expr is a whole object or a spec, as in
+// desired.Spec, found.Spec.Template or an object variable, and a struct
+// (or a pointer to one) when its type is known.
+func (pd *PatternDetector) isStateOperand(expr ast.Expr) bool {
+	inner := ast.Unparen(expr)
+	if unary, ok := inner.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		inner = unary.X
+	}
+	if star, ok := inner.(*ast.StarExpr); ok {
+		inner = star.X
+	}
+	switch e := inner.(type) {
+	case *ast.Ident, *ast.CompositeLit:
+	case *ast.SelectorExpr:
+		if !selectsSpec(e) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if t := pd.pkg.TypesInfo.TypeOf(expr); t != nil {
+			if ptr, ok := t.Underlying().(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			_, isStruct := t.Underlying().(*types.Struct)
+			return isStruct
+		}
+		pd.warnf("line %d: unresolved type of %s in comparison", pd.fset.Position(expr.Pos()).Line, types.ExprString(expr))
+	}
+	return true
+}
+
+// selectsSpec checks if a field path goes through a Spec field, e.g.
+// obj.Spec or obj.Spec.Template.Spec.
+func selectsSpec(sel *ast.SelectorExpr) bool {
+	for {
+		if sel.Sel.Name == "Spec" {
+			return true
+		}
+		x, ok := sel.X.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		sel = x
+	}
+}
+
+// getCall returns the client Get call assigned by stmt, if any.
+func (pd *PatternDetector) getCall(stmt ast.Stmt) *ast.CallExpr {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return nil
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) < 3 {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Get" || !pd.isClientCall(sel) {
+		return nil
+	}
+	return call
+}
+
+// findObjectCall finds a client call to one of methods on the object
+// variable obj within node.
+func (pd *PatternDetector) findObjectCall(node ast.Node, obj string, methods ...string) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !pd.isClientCall(sel) || rootIdent(call.Args[1]) != obj {
+			return true
+		}
+		for _, m := range methods {
+			if sel.Sel.Name == m {
+				found = call
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// derivesFromReq checks if expression references the request parameter or a
+// local variable derived from it.
+func (pd *PatternDetector) derivesFromReq(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			if ident.Name == pd.reqParamName || pd.reqDerived[ident.Name] {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// listOptionsLiteral returns the composite literal if expr is a
+// (possibly address-of) ListOptions literal.
+func listOptionsLiteral(expr ast.Expr) *ast.CompositeLit {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	switch t := lit.Type.(type) {
+	case *ast.Ident:
+		if strings.HasSuffix(t.Name, "ListOptions") {
+			return lit
+		}
+	case *ast.SelectorExpr:
+		if strings.HasSuffix(t.Sel.Name, "ListOptions") {
+			return lit
+		}
+	}
+	return nil
+}
+
 // isReqNamespacedName checks for patterns like req.NamespacedName.
 func (pd *PatternDetector) isReqNamespacedName(expr ast.Expr) bool {
 	sel, ok := expr.(*ast.SelectorExpr)
@@ -416,6 +2932,51 @@ func (pd *PatternDetector) isNamespaceOption(expr ast.Expr) bool {
 	return false
 }
 
+// isConfigValue checks if expr reads configuration rather than the request:
+// a field of the receiver (r.watchNamespace, r.Config.Namespace) or an
+// os.Getenv call.
+func (pd *PatternDetector) isConfigValue(expr ast.Expr) bool {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		return ok && sel.Sel.Name == "Getenv" && pd.isPkgSelector(sel, "os", "os")
+	}
+	if _, ok := expr.(*ast.SelectorExpr); !ok {
+		return false
+	}
+	return pd.recvName != "" && rootIdent(expr) == pd.recvName
+}
+
+// constString evaluates expr as a constant string, from type information or
+// a string literal.
+func (pd *PatternDetector) constString(expr ast.Expr) (string, bool) {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if tv, ok := pd.pkg.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value), true
+		}
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if s, err := strconv.Unquote(lit.Value); err == nil {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// isAllNamespaces checks if a namespace expression selects all namespaces:
+// the empty string or metav1.NamespaceAll.
+func (pd *PatternDetector) isAllNamespaces(expr ast.Expr) bool {
+	if sel, ok := expr.(*ast.SelectorExpr); ok && sel.Sel.Name == "NamespaceAll" {
+		return true
+	}
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if tv, ok := pd.pkg.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value) == ""
+		}
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING && (lit.Value == `""` || lit.Value == "``")
+}
+
 // isLabelMatchOption checks if an expression is a label matching option.
 func (pd *PatternDetector) isLabelMatchOption(expr ast.Expr) bool {
 	// Look for MatchingLabels(...) or MatchingFields(...).
@@ -434,6 +2995,29 @@ func (pd *PatternDetector) isLabelMatchOption(expr ast.Expr) bool {
 	return false
 }
 
+// indexedFieldMatch returns the key of a List option selecting on a field
+// index from setup with a value derived from the request, as in
+// client.MatchingFields{ownerKey: req.Name}, or "" if there is none.
+func (pd *PatternDetector) indexedFieldMatch(expr ast.Expr) string {
+	if len(pd.indexedFields) == 0 {
+		return ""
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || !strings.HasSuffix(types.ExprString(lit.Type), "MatchingFields") {
+		return ""
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key := pd.stringValue(kv.Key); pd.indexedFields[key] && pd.derivesFromReq(kv.Value) {
+			return key
+		}
+	}
+	return ""
+}
+
 // isNotFoundCheck checks for apierrors.IsNotFound(err).
 func (pd *PatternDetector) isNotFoundCheck(expr ast.Expr) bool {
 	call, ok := expr.(*ast.CallExpr)
@@ -490,6 +3074,9 @@ func (pd *PatternDetector) extractSnippet(node ast.Node) string {
 		end := pd.fset.Position(node.End()).Offset
 		if start >= 0 && end <= len(pd.fileData) && start < end {
 			snippet := string(pd.fileData[start:end])
+			if pd.FormatSnippets {
+				return pd.formatSnippet(snippet)
+			}
 			return pd.truncateSnippet(snippet)
 		}
 	}
@@ -504,11 +3091,35 @@ func (pd *PatternDetector) extractSnippet(node ast.Node) string {
 		}
 	}
 
+	if pd.FormatSnippets {
+		return pd.formatSnippet(buf.String())
+	}
 	return pd.truncateSnippet(buf.String())
 }
 
-// truncateSnippet truncates a snippet to a reasonable length.
+// formatSnippet runs a snippet through gofmt, keeping its line structure.
+// Snippets that don't parse as statements are kept as-is apart from trimming.
+func (pd *PatternDetector) formatSnippet(s string) string {
+	if formatted, err := format.Source([]byte(s)); err == nil {
+		s = string(formatted)
+	}
+
+	s = strings.TrimSpace(s)
+	if pd.SnippetLength > 0 && len(s) > pd.SnippetLength {
+		s = s[:pd.SnippetLength] + "..."
+	}
+
+	return s
+}
+
+// truncateSnippet truncates a snippet to the configured length.
 func (pd *PatternDetector) truncateSnippet(s string) string {
+	return truncateSnippet(s, pd.SnippetLength)
+}
+
+// truncateSnippet collapses whitespace and truncates s to maxLen bytes.
+// A maxLen of 0 means no truncation.
+func truncateSnippet(s string, maxLen int) string {
 	// Remove leading/trailing whitespace.
 	s = strings.TrimSpace(s)
 
@@ -516,8 +3127,7 @@ func (pd *PatternDetector) truncateSnippet(s string) string {
 	s = strings.Join(strings.Fields(s), " ")
 
 	// Truncate if too long.
-	maxLen := 200
-	if len(s) > maxLen {
+	if maxLen > 0 && len(s) > maxLen {
 		s = s[:maxLen] + "..."
 	}
 
@@ -549,8 +3159,9 @@ func ReadFileDataFromPath(path string) ([]byte, error) {
 	return nil, nil
 }
 
-// ExtractSnippetFromSource extracts a snippet from source using positions.
-func ExtractSnippetFromSource(fset *token.FileSet, node ast.Node, src []byte) string {
+// ExtractSnippetFromSource extracts a snippet from source using positions,
+// truncated to maxLen bytes (0 means no truncation).
+func ExtractSnippetFromSource(fset *token.FileSet, node ast.Node, src []byte, maxLen int) string {
 	if src == nil || len(src) == 0 {
 		return ""
 	}
@@ -562,16 +3173,7 @@ func ExtractSnippetFromSource(fset *token.FileSet, node ast.Node, src []byte) st
 		return ""
 	}
 
-	snippet := string(src[start:end])
-	snippet = strings.TrimSpace(snippet)
-	snippet = strings.Join(strings.Fields(snippet), " ")
-
-	maxLen := 200
-	if len(snippet) > maxLen {
-		snippet = snippet[:maxLen] + "..."
-	}
-
-	return snippet
+	return truncateSnippet(string(src[start:end]), maxLen)
 }
 
 // DummyReader implements io.Reader for testing.
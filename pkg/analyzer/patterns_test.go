@@ -0,0 +1,275 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestTruncateSnippet(t *testing.T) {
+	tests := []struct {
+		in     string
+		maxLen int
+		want   string
+	}{
+		{"r.Client.List(ctx, &pods)", 0, "r.Client.List(ctx, &pods)"},
+		{"  r.Client.List(ctx,\n\t\t&pods)\n", 0, "r.Client.List(ctx, &pods)"},
+		{"r.Client.List(ctx, &pods)", 13, "r.Client.List..."},
+		{"r.Client.List(ctx, &pods)", 25, "r.Client.List(ctx, &pods)"},
+	}
+	for _, tt := range tests {
+		if got := truncateSnippet(tt.in, tt.maxLen); got != tt.want {
+			t.Errorf("truncateSnippet(%q, %d) = %q, want %q", tt.in, tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestExtractSnippetFromSource(t *testing.T) {
+	src := []byte("package p\n\nvar x = f(a,\n\tb)\n")
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decl := file.Decls[0]
+	if got, want := ExtractSnippetFromSource(fset, decl, src, 0), "var x = f(a, b)"; got != want {
+		t.Errorf("ExtractSnippetFromSource() = %q, want %q", got, want)
+	}
+	if got, want := ExtractSnippetFromSource(fset, decl, src, 5), "var x..."; got != want {
+		t.Errorf("ExtractSnippetFromSource(maxLen 5) = %q, want %q", got, want)
+	}
+	if got := ExtractSnippetFromSource(fset, decl, nil, 0); got != "" {
+		t.Errorf("ExtractSnippetFromSource(nil source) = %q, want empty", got)
+	}
+}
+
+func TestFormatSnippet(t *testing.T) {
+	tests := []struct {
+		in     string
+		maxLen int
+		want   string
+	}{
+		{"if err!=nil {\n        return   err\n    }", 0, "if err != nil {\n\treturn err\n}"},
+		{"r.Client.List(ctx ,&pods)", 0, "r.Client.List(ctx, &pods)"},
+		// Unparseable fragments are kept as they are.
+		{"  Owns(&v1.Deployment{}).  ", 0, "Owns(&v1.Deployment{})."},
+		{"r.Client.List(ctx, &pods)", 13, "r.Client.List..."},
+	}
+	for _, tt := range tests {
+		pd := &PatternDetector{SnippetLength: tt.maxLen}
+		if got := pd.formatSnippet(tt.in); got != tt.want {
+			t.Errorf("formatSnippet(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// reconcileBody is a package with a WidgetReconciler whose Reconcile method
+// has the given body. It imports context and the given import specs, such as
+// `"time"` or `apierrors "k8s.io/apimachinery/pkg/api/errors"`.
+func reconcileBody(imports []string, body string) string {
+	var sb strings.Builder
+	sb.WriteString("package controllers\n\nimport (\n\t\"context\"\n")
+	for _, imp := range imports {
+		sb.WriteString("\t" + imp + "\n")
+	}
+	sb.WriteString(`)
+
+type Request struct{ Namespace, Name string }
+type Result struct {
+	Requeue      bool
+	RequeueAfter int64
+}
+
+type Client interface {
+	Get(ctx context.Context, key interface{}, obj interface{}) error
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+	Create(ctx context.Context, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+	Status() Client
+}
+
+type Widget struct {
+	Spec   struct{ Replicas int }
+	Status struct{ Ready int }
+}
+
+type WidgetReconciler struct{ Client Client }
+
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+`)
+	sb.WriteString(body)
+	sb.WriteString("\n}\n")
+	return sb.String()
+}
+
+// analyzeOne analyzes src and returns its only reconciler.
+func analyzeOne(t *testing.T, src string) models.Reconciler {
+	t.Helper()
+	a := NewAnalyzer(t.TempDir(), false)
+	reconcilers, err := a.AnalyzeSource(map[string]string{"controllers/widget.go": src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reconcilers) != 1 {
+		t.Fatalf("found %d reconcilers, want 1", len(reconcilers))
+	}
+	return reconcilers[0]
+}
+
+// signalTypes returns the types of the signals of r, sorted by line.
+func signalTypes(r models.Reconciler) []string {
+	var types []string
+	for _, sig := range r.Signals {
+		types = append(types, sig.Type)
+	}
+	return types
+}
+
+func TestWaitPolling(t *testing.T) {
+	tests := []struct {
+		name string
+		call string
+		want []string
+	}{
+		{name: "short readiness wait", call: "wait.PollImmediate(time.Second, 30*time.Second, ready)", want: []string{models.SignalPollWait}},
+		{name: "long timeout", call: "wait.PollImmediate(time.Second, 5*time.Minute, ready)", want: []string{models.SignalPollLoop}},
+		{name: "non-constant timeout", call: "wait.Poll(time.Second, timeout, ready)", want: []string{models.SignalPollLoop}},
+		{name: "context timeout", call: "wait.PollUntilContextTimeout(ctx, time.Second, time.Minute, true, ready)", want: []string{models.SignalPollWait}},
+		{name: "unbounded", call: "wait.Until(func() {}, time.Minute, ctx.Done())", want: []string{models.SignalPollLoop}},
+		{name: "not a poll", call: "wait.Jitter(time.Second, 0.1)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body := "\tready := func() (bool, error) { return true, nil }\n\ttimeout := time.Minute\n\t_, _ = ready, timeout\n\t" + tt.call + "\n\treturn Result{}, nil"
+			r := analyzeOne(t, reconcileBody([]string{`"time"`, `"k8s.io/apimachinery/pkg/util/wait"`}, body))
+			if got := signalTypes(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signals = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenericReads(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "meta.Accessor", body: "accessor, _ := meta.Accessor(obj)\n\t_ = accessor", want: true},
+		{name: "unstructured helper", body: `_, _, _ = unstructured.NestedString(u, "spec", "host")`, want: true},
+		{name: "unstructured object", body: "_ = &unstructured.Unstructured{}", want: true},
+		{name: "typed", body: "var widget Widget\n\t_ = r.Client.Get(ctx, req, &widget)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body := "\tvar obj interface{}\n\tvar u map[string]interface{}\n\t_, _ = obj, u\n\t" + tt.body + "\n\treturn Result{}, nil"
+			imports := []string{`"k8s.io/apimachinery/pkg/api/meta"`, `"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"`}
+			if r := analyzeOne(t, reconcileBody(imports, body)); r.IsGeneric != tt.want {
+				t.Errorf("IsGeneric = %t, want %t", r.IsGeneric, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequeueBehavior(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		always, never bool
+	}{
+		{name: "always requeues", body: "if req.Name == \"\" {\n\t\treturn Result{Requeue: true}, nil\n\t}\n\treturn Result{RequeueAfter: 30}, nil", always: true},
+		{name: "never requeues", body: "if req.Name == \"\" {\n\t\treturn Result{Requeue: false}, nil\n\t}\n\treturn Result{}, nil", never: true},
+		{name: "mixed", body: "if req.Name == \"\" {\n\t\treturn Result{Requeue: true}, nil\n\t}\n\treturn Result{}, nil"},
+		{name: "opaque result", body: "res := Result{}\n\treturn res, nil"},
+		{name: "closures ignored", body: "f := func() (Result, error) { return Result{Requeue: true}, nil }\n\t_ = f\n\treturn Result{RequeueAfter: 0}, nil", never: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := analyzeOne(t, reconcileBody(nil, "\t"+tt.body))
+			if r.RequeuesAlways != tt.always || r.NeverRequeues != tt.never {
+				t.Errorf("RequeuesAlways, NeverRequeues = %t, %t, want %t, %t", r.RequeuesAlways, r.NeverRequeues, tt.always, tt.never)
+			}
+		})
+	}
+}
+
+func TestNotFoundHandling(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler string
+		want    string
+	}{
+		{name: "create", handler: "return Result{}, r.Client.Create(ctx, &widget)", want: models.SignalCreateOnMissing},
+		{name: "nested create", handler: "if req.Namespace != \"\" {\n\t\t\t\t_ = r.Client.Create(ctx, &widget)\n\t\t\t}\n\t\t\treturn Result{}, nil", want: models.SignalCreateOnMissing},
+		{name: "ignore", handler: "return Result{}, nil", want: models.SignalNotFoundIgnore},
+		{name: "delete logic", handler: "return Result{}, cleanup()", want: models.SignalNotFoundEarlyReturn},
+		{name: "other client write", handler: "return Result{}, r.Client.Update(ctx, &widget)", want: models.SignalNotFoundEarlyReturn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body := "\tcleanup := func() error { return nil }\n\t_ = cleanup\n" +
+				"\tvar widget Widget\n\tif err := r.Client.Get(ctx, req, &widget); err != nil {\n\t\tif apierrors.IsNotFound(err) {\n\t\t\t" + tt.handler + "\n\t\t}\n\t\treturn Result{}, err\n\t}\n\treturn Result{}, nil"
+			r := analyzeOne(t, reconcileBody([]string{`apierrors "k8s.io/apimachinery/pkg/api/errors"`}, body))
+			var got []string
+			for _, typ := range signalTypes(r) {
+				switch typ {
+				case models.SignalCreateOnMissing, models.SignalNotFoundIgnore, models.SignalNotFoundEarlyReturn:
+					got = append(got, typ)
+				}
+			}
+			if want := []string{tt.want}; !reflect.DeepEqual(got, want) {
+				t.Errorf("NotFound signals = %v, want %v (all: %v)", got, want, signalTypes(r))
+			}
+		})
+	}
+}
+
+func TestDirection(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "status",
+			body: "widget.Status.Ready = widget.Spec.Replicas\n\treturn Result{}, r.Client.Status().Update(ctx, &widget)",
+			want: models.DirectionStatus,
+		},
+		{
+			name: "status from a spec condition",
+			body: "if widget.Spec.Replicas > 0 {\n\t\twidget.Status.Ready = 1\n\t}\n\treturn Result{}, r.Client.Status().Update(ctx, &widget)",
+			want: models.DirectionStatus,
+		},
+		{
+			name: "spec write",
+			body: "widget.Spec.Replicas = 3\n\treturn Result{}, nil",
+			want: models.DirectionOrchestrator,
+		},
+		{
+			name: "object write",
+			body: "widget.Status.Ready = widget.Spec.Replicas\n\treturn Result{}, r.Client.Update(ctx, &widget)",
+			want: models.DirectionOrchestrator,
+		},
+		{
+			name: "status without reading spec",
+			body: "widget.Status.Ready = 1\n\treturn Result{}, r.Client.Status().Update(ctx, &widget)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body := "\tvar widget Widget\n\tif err := r.Client.Get(ctx, req, &widget); err != nil {\n\t\treturn Result{}, err\n\t}\n\t" + tt.body
+			if r := analyzeOne(t, reconcileBody(nil, body)); r.Direction != tt.want {
+				t.Errorf("Direction = %q, want %q", r.Direction, tt.want)
+			}
+		})
+	}
+}
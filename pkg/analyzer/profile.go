@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadProfile reads a scoring profile from a JSON or YAML file. Thresholds
+// missing from the file default to the built-in ones.
+func LoadProfile(path string) (models.ScoringProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.ScoringProfile{}, err
+	}
+
+	profile := models.DefaultProfile()
+	profile.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &profile)
+	default:
+		err = json.Unmarshal(data, &profile)
+	}
+	if err != nil {
+		return models.ScoringProfile{}, fmt.Errorf("failed to parse profile %s: %w", path, err)
+	}
+
+	t := profile.Thresholds
+	if !(t.EdgeTriggered <= t.MostlyEdge && t.MostlyEdge <= t.MostlySoTW) {
+		return models.ScoringProfile{}, fmt.Errorf("profile %s: thresholds must be non-decreasing", path)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestLoadProfile(t *testing.T) {
+	defaults := models.DefaultProfile().Thresholds
+	tests := []struct {
+		name, file, content string
+		want                models.ScoringProfile
+		wantErr             bool
+	}{
+		{
+			name:    "yaml with defaults",
+			file:    "strict.yaml",
+			content: "scores:\n  list_unscoped: 5\n",
+			want:    models.ScoringProfile{Name: "strict", Scores: map[string]int{"list_unscoped": 5}, Thresholds: defaults},
+		},
+		{
+			name:    "json with name and thresholds",
+			file:    "p.json",
+			content: `{"name": "custom", "thresholds": {"edge_triggered": -5, "mostly_edge": -1, "mostly_sotw": 4}}`,
+			want:    models.ScoringProfile{Name: "custom", Thresholds: models.Thresholds{EdgeTriggered: -5, MostlyEdge: -1, MostlySoTW: 4}},
+		},
+		{
+			name:    "decreasing thresholds",
+			file:    "bad.yaml",
+			content: "thresholds:\n  edge_triggered: 1\n  mostly_edge: 0\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			file:    "bad.json",
+			content: "{",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadProfile(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("LoadProfile() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadProfile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
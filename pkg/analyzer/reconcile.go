@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
@@ -12,8 +13,20 @@ import (
 // ReconcileFinder finds Reconcile methods in packages.
 type ReconcileFinder struct {
 	fset *token.FileSet
+
+	// IncludeTests disables skipping of _test.go files and _test packages.
+	IncludeTests bool
+
+	// StrictSignature requires the request and result to resolve to exactly
+	// controller-runtime's reconcile.Request and reconcile.Result, rejecting
+	// lookalike types and generic or object-typed signatures.
+	StrictSignature bool
 }
 
+// reconcilePkgPath is the package defining reconcile.Request and
+// reconcile.Result; ctrl.Request and ctrl.Result are aliases of them.
+const reconcilePkgPath = "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
 // NewReconcileFinder creates a new ReconcileFinder.
 func NewReconcileFinder(fset *token.FileSet) *ReconcileFinder {
 	return &ReconcileFinder{fset: fset}
@@ -26,22 +39,57 @@ type ReconcileFunc struct {
 	Func         *ast.FuncDecl
 	ReceiverType string
 	ReceiverPkg  string
+
+	// Setup is the SetupWithManager method on the same receiver, if any,
+	// or else the package function registering the controller through
+	// controller.New.
+	Setup *ast.FuncDecl
+
+	// WebhookAttached is set when the receiver also implements webhook
+	// methods (Default, ValidateCreate, ValidateUpdate, ValidateDelete).
+	WebhookAttached bool
+
+	// Functional is set for function literals converted to a Reconciler,
+	// e.g. reconcile.Func(func(ctx, req) ...). Func then wraps the literal
+	// in a synthetic declaration and ReceiverType names the enclosing
+	// declaration, e.g. "NewController.func1".
+	Functional bool
+
+	// ObjectType is the reconciled object type, e.g. "corev1.Pod", for
+	// reconcile.ObjectReconciler[T] implementations that take the object
+	// itself instead of a Request.
+	ObjectType string
 }
 
+// webhookMethods are the defaulting/validating webhook method names.
+var webhookMethods = []string{"Default", "ValidateCreate", "ValidateUpdate", "ValidateDelete"}
+
 // FindReconcileFunctions finds all Reconcile methods matching the controller-runtime signature.
 func (rf *ReconcileFinder) FindReconcileFunctions(pkgs []*packages.Package) []ReconcileFunc {
 	var results []ReconcileFunc
 
+	// With tests loaded, a package's files appear both in the package and in
+	// its test variant, so remember which functions were already found.
+	seen := make(map[string]bool)
+
 	for _, pkg := range pkgs {
 		// Skip test packages.
-		if strings.HasSuffix(pkg.PkgPath, "_test") {
+		if !rf.IncludeTests && strings.HasSuffix(pkg.PkgPath, "_test") {
 			continue
 		}
 
+		// Skip generated test main packages.
+		if strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+
+		methods := rf.receiverMethods(pkg)
+		controllerFuncs := controllerNewFuncs(pkg)
+
 		for _, file := range pkg.Syntax {
 			// Skip test files.
 			fileName := rf.fset.Position(file.Pos()).Filename
-			if strings.HasSuffix(fileName, "_test.go") {
+			if !rf.IncludeTests && strings.HasSuffix(fileName, "_test.go") {
 				continue
 			}
 
@@ -66,6 +114,13 @@ func (rf *ReconcileFinder) FindReconcileFunctions(pkgs []*packages.Package) []Re
 					return true
 				}
 
+				pos := rf.fset.Position(fn.Pos())
+				key := fmt.Sprintf("%s:%d", pos.Filename, pos.Line)
+				if seen[key] {
+					return true
+				}
+				seen[key] = true
+
 				recvType, recvPkg := rf.extractReceiverInfo(fn, pkg)
 				results = append(results, ReconcileFunc{
 					Pkg:          pkg,
@@ -73,21 +128,338 @@ func (rf *ReconcileFinder) FindReconcileFunctions(pkgs []*packages.Package) []Re
 					Func:         fn,
 					ReceiverType: recvType,
 					ReceiverPkg:  recvPkg,
+					Setup:        methods[recvType]["SetupWithManager"],
+					ObjectType:   rf.reconciledObjectType(fn, pkg),
 				})
+				if results[len(results)-1].Setup == nil {
+					results[len(results)-1].Setup = legacySetup(pkg, recvType, controllerFuncs)
+				}
+				if results[len(results)-1].Setup == nil {
+					results[len(results)-1].Setup = pickControllerFunc(controllerFuncs, file)
+				}
+				for _, m := range webhookMethods {
+					if methods[recvType][m] != nil {
+						results[len(results)-1].WebhookAttached = true
+					}
+				}
 
 				return true
 			})
+
+			for _, f := range rf.findFunctionalReconcilers(pkg, file) {
+				pos := rf.fset.Position(f.Func.Pos())
+				key := fmt.Sprintf("%s:%d", pos.Filename, pos.Line)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				results = append(results, f)
+			}
+		}
+	}
+
+	return results
+}
+
+// findFunctionalReconcilers finds function literals with the Reconcile
+// signature that are converted to a Reconciler function type.
+func (rf *ReconcileFinder) findFunctionalReconcilers(pkg *packages.Package, file *ast.File) []ReconcileFunc {
+	var results []ReconcileFunc
+
+	for _, decl := range file.Decls {
+		var name string
+		var setup *ast.FuncDecl
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			name = d.Name.Name
+			if recvType, _ := rf.extractReceiverInfo(d, pkg); recvType != "" {
+				name = recvType + "." + name
+			}
+			// The enclosing function usually registers the reconciler.
+			setup = d
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) > 0 {
+					name = vs.Names[0].Name
+					break
+				}
+			}
 		}
+		if name == "" {
+			continue
+		}
+
+		n := 0
+		ast.Inspect(decl, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 || !isReconcileFuncConversion(call, pkg) {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			fn := &ast.FuncDecl{
+				Name: ast.NewIdent("Reconcile"),
+				Type: lit.Type,
+				Body: lit.Body,
+			}
+			if !rf.matchesReconcileSignature(fn, pkg) {
+				return true
+			}
+
+			n++
+			results = append(results, ReconcileFunc{
+				Pkg:          pkg,
+				File:         file,
+				Func:         fn,
+				ReceiverType: fmt.Sprintf("%s.func%d", name, n),
+				ReceiverPkg:  pkg.PkgPath,
+				Setup:        setup,
+				Functional:   true,
+			})
+			return true
+		})
 	}
 
 	return results
 }
 
+// isReconcileFuncConversion checks if call converts its argument to a
+// function type implementing Reconciler, such as reconcile.Func or
+// reconcile.TypedFunc[T]. Without type information it matches on the name.
+func isReconcileFuncConversion(call *ast.CallExpr, pkg *packages.Package) bool {
+	if pkg.TypesInfo != nil {
+		if tv, ok := pkg.TypesInfo.Types[call.Fun]; ok {
+			if !tv.IsType() {
+				return false
+			}
+			for _, t := range []types.Type{tv.Type, types.NewPointer(tv.Type)} {
+				if types.NewMethodSet(t).Lookup(nil, "Reconcile") != nil {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	fun := call.Fun
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name == "Func" || f.Name == "TypedFunc"
+	case *ast.SelectorExpr:
+		return f.Sel.Name == "Func" || f.Sel.Name == "TypedFunc"
+	}
+	return false
+}
+
+// controllerFunc is a function registering a controller with controller.New.
+type controllerFunc struct {
+	file *ast.File
+	fn   *ast.FuncDecl
+}
+
+// controllerNewFuncs finds the package's functions that call controller.New,
+// the low-level alternative to the controller builder.
+func controllerNewFuncs(pkg *packages.Package) []controllerFunc {
+	var funcs []controllerFunc
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			found := false
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "New" {
+						if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "controller" {
+							found = true
+						}
+					}
+				}
+				return !found
+			})
+			if found {
+				funcs = append(funcs, controllerFunc{file: file, fn: fn})
+			}
+		}
+	}
+	return funcs
+}
+
+// pickControllerFunc picks the controller.New function for a Reconcile
+// method in file: the one in the same file, or the package's only one.
+func pickControllerFunc(funcs []controllerFunc, file *ast.File) *ast.FuncDecl {
+	var inFile []*ast.FuncDecl
+	for _, f := range funcs {
+		if f.file == file {
+			inFile = append(inFile, f.fn)
+		}
+	}
+	switch {
+	case len(inFile) == 1:
+		return inFile[0]
+	case len(inFile) == 0 && len(funcs) == 1:
+		return funcs[0].fn
+	}
+	return nil
+}
+
+// legacySetup follows the Add(mgr) -> add(mgr, newReconciler(mgr)) chain of
+// kubebuilder v1 and operator-sdk projects to the controller.New function
+// registering recvType: the function passed the result of a constructor
+// returning a recvType literal, directly or through a local variable.
+func legacySetup(pkg *packages.Package, recvType string, controllerFuncs []controllerFunc) *ast.FuncDecl {
+	adders := make(map[string]*ast.FuncDecl)
+	for _, f := range controllerFuncs {
+		if f.fn.Recv == nil {
+			adders[f.fn.Name.Name] = f.fn
+		}
+	}
+	constructors := reconcilerConstructors(pkg, recvType)
+	if len(adders) == 0 || len(constructors) == 0 {
+		return nil
+	}
+
+	isConstructorCall := func(expr ast.Expr) bool {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		return ok && constructors[ident.Name]
+	}
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			// Locals holding a constructed reconciler, as in r := newReconciler(mgr).
+			built := make(map[string]bool)
+			var setup *ast.FuncDecl
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if setup != nil {
+					return false
+				}
+				switch node := n.(type) {
+				case *ast.AssignStmt:
+					if len(node.Lhs) != len(node.Rhs) {
+						return true
+					}
+					for i, rhs := range node.Rhs {
+						if ident, ok := node.Lhs[i].(*ast.Ident); ok && isConstructorCall(rhs) {
+							built[ident.Name] = true
+						}
+					}
+				case *ast.CallExpr:
+					ident, ok := node.Fun.(*ast.Ident)
+					if !ok || adders[ident.Name] == nil {
+						return true
+					}
+					for _, arg := range node.Args {
+						if local, ok := arg.(*ast.Ident); isConstructorCall(arg) || ok && built[local.Name] {
+							setup = adders[ident.Name]
+						}
+					}
+				}
+				return true
+			})
+			if setup != nil {
+				return setup
+			}
+		}
+	}
+	return nil
+}
+
+// reconcilerConstructors returns the names of the package-level functions
+// returning a recvType literal, e.g. newReconciler returning
+// &ReconcileMemcached{client: mgr.GetClient()}.
+func reconcilerConstructors(pkg *packages.Package, recvType string) map[string]bool {
+	names := make(map[string]bool)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if _, ok := n.(*ast.FuncLit); ok {
+					return false
+				}
+				ret, ok := n.(*ast.ReturnStmt)
+				if !ok || len(ret.Results) == 0 {
+					return true
+				}
+				expr := ret.Results[0]
+				if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+					expr = unary.X
+				}
+				if lit, ok := expr.(*ast.CompositeLit); ok && typeName(lit.Type) == recvType {
+					names[fn.Name.Name] = true
+				}
+				return true
+			})
+		}
+	}
+	return names
+}
+
+// typeName returns the name of a possibly package-qualified or generic type
+// expression, e.g. "Foo" for pkg.Foo or Foo[T], or "".
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return typeName(t.X)
+	case *ast.IndexListExpr:
+		return typeName(t.X)
+	}
+	return ""
+}
+
+// receiverMethods indexes a package's methods by receiver type name and method name.
+func (rf *ReconcileFinder) receiverMethods(pkg *packages.Package) map[string]map[string]*ast.FuncDecl {
+	methods := make(map[string]map[string]*ast.FuncDecl)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			recvType, _ := rf.extractReceiverInfo(fn, pkg)
+			if methods[recvType] == nil {
+				methods[recvType] = make(map[string]*ast.FuncDecl)
+			}
+			methods[recvType][fn.Name.Name] = fn
+		}
+	}
+	return methods
+}
+
 // matchesReconcileSignature checks if function matches controller-runtime Reconcile signature.
 // Expected: func (r *T) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
+// The request may also be a type parameter of a generic receiver
+// (reconcile.TypedReconciler[request]) or, for reconcile.ObjectReconciler[T],
+// the object itself. Kubebuilder v2-era projects use the context-less
+// Reconcile(req reconcile.Request), registered from an add(mgr, r) function
+// calling controller.New (see controllerNewFuncs).
 func (rf *ReconcileFinder) matchesReconcileSignature(fn *ast.FuncDecl, pkg *packages.Package) bool {
-	// Check parameters: (ctx context.Context, req ctrl.Request).
-	if fn.Type.Params == nil || len(fn.Type.Params.List) < 2 {
+	// Check parameters: (ctx context.Context, req ctrl.Request), or just
+	// (req reconcile.Request) before controller-runtime v0.7.
+	if fn.Type.Params == nil || len(fn.Type.Params.List) < 1 {
 		return false
 	}
 
@@ -97,14 +469,26 @@ func (rf *ReconcileFinder) matchesReconcileSignature(fn *ast.FuncDecl, pkg *pack
 	}
 
 	// Check first parameter is context.Context.
-	firstParam := fn.Type.Params.List[0]
-	if !rf.isContextType(firstParam.Type, pkg) {
+	legacy := len(fn.Type.Params.List) == 1
+	if !legacy && !rf.isContextType(fn.Type.Params.List[0].Type, pkg) {
 		return false
 	}
+	reqParam := requestParam(fn)
+
+	if rf.StrictSignature {
+		return rf.isReconcileType(reqParam.Type, pkg, "Request") &&
+			rf.isReconcileType(fn.Type.Results.List[0].Type, pkg, "Result") &&
+			rf.isErrorType(fn.Type.Results.List[1].Type, pkg)
+	}
 
-	// Check second parameter type name contains "Request".
-	secondParam := fn.Type.Params.List[1]
-	if !rf.isRequestType(secondParam.Type, pkg) {
+	// Check request parameter type name contains "Request". Generic and
+	// object-typed requests postdate the context-less signature.
+	if legacy {
+		if !rf.isRequestType(reqParam.Type, pkg) {
+			return false
+		}
+	} else if !rf.isRequestType(reqParam.Type, pkg) && !isReceiverTypeParam(fn, reqParam.Type) &&
+		!rf.isObjectType(reqParam.Type, pkg) {
 		return false
 	}
 
@@ -140,6 +524,14 @@ func (rf *ReconcileFinder) extractReceiverInfo(fn *ast.FuncDecl, pkg *packages.P
 		recvType = star.X
 	}
 
+	// Handle generic receiver: T[P] -> T.
+	switch t := recvType.(type) {
+	case *ast.IndexExpr:
+		recvType = t.X
+	case *ast.IndexListExpr:
+		recvType = t.X
+	}
+
 	// Get type name.
 	var typeName string
 	switch t := recvType.(type) {
@@ -154,6 +546,65 @@ func (rf *ReconcileFinder) extractReceiverInfo(fn *ast.FuncDecl, pkg *packages.P
 	return typeName, pkg.PkgPath
 }
 
+// isReceiverTypeParam checks if expr names a type parameter of fn's
+// generic receiver, as in func (r *R[req]) Reconcile(ctx, r req).
+func isReceiverTypeParam(fn *ast.FuncDecl, expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return false
+	}
+
+	recvType := fn.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+
+	var params []ast.Expr
+	switch t := recvType.(type) {
+	case *ast.IndexExpr:
+		params = []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		params = t.Indices
+	}
+	for _, p := range params {
+		if p, ok := p.(*ast.Ident); ok && p.Name == ident.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// isObjectType checks if a type implements client.Object, as the parameter
+// of a reconcile.ObjectReconciler does. It requires type information.
+func (rf *ReconcileFinder) isObjectType(expr ast.Expr, pkg *packages.Package) bool {
+	if pkg.TypesInfo == nil {
+		return false
+	}
+	t := pkg.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+	for _, m := range []string{"GetName", "GetNamespace", "DeepCopyObject"} {
+		if obj, _, _ := types.LookupFieldOrMethod(t, true, nil, m); obj == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// reconciledObjectType returns the object type taken by an ObjectReconciler's
+// Reconcile method, or "" if fn takes a request.
+func (rf *ReconcileFinder) reconciledObjectType(fn *ast.FuncDecl, pkg *packages.Package) string {
+	param := requestParam(fn).Type
+	if rf.isRequestType(param, pkg) || isReceiverTypeParam(fn, param) {
+		return ""
+	}
+	if star, ok := param.(*ast.StarExpr); ok {
+		param = star.X
+	}
+	return types.ExprString(param)
+}
+
 // isContextType checks if a type is context.Context.
 func (rf *ReconcileFinder) isContextType(expr ast.Expr, pkg *packages.Package) bool {
 	return rf.typeNameContains(expr, pkg, "Context")
@@ -169,6 +620,19 @@ func (rf *ReconcileFinder) isResultType(expr ast.Expr, pkg *packages.Package) bo
 	return rf.typeNameContains(expr, pkg, "Result")
 }
 
+// isReconcileType checks if expr resolves to the named type in
+// controller-runtime's reconcile package. It needs type information.
+func (rf *ReconcileFinder) isReconcileType(expr ast.Expr, pkg *packages.Package, name string) bool {
+	if pkg.TypesInfo == nil {
+		return false
+	}
+	named, ok := types.Unalias(pkg.TypesInfo.TypeOf(expr)).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == reconcilePkgPath && named.Obj().Name() == name
+}
+
 // isErrorType checks if a type is error.
 func (rf *ReconcileFinder) isErrorType(expr ast.Expr, pkg *packages.Package) bool {
 	if ident, ok := expr.(*ast.Ident); ok {
@@ -199,20 +663,31 @@ func (rf *ReconcileFinder) typeNameContains(expr ast.Expr, pkg *packages.Package
 
 // ExtractReqParamName extracts the request parameter name from the function signature.
 func ExtractReqParamName(fn *ast.FuncDecl) string {
-	if fn.Type.Params == nil || len(fn.Type.Params.List) < 2 {
+	if fn.Type.Params == nil || len(fn.Type.Params.List) < 1 {
 		return "req" // default fallback
 	}
 
-	secondParam := fn.Type.Params.List[1]
-	if len(secondParam.Names) > 0 {
-		return secondParam.Names[0].Name
+	if param := requestParam(fn); len(param.Names) > 0 {
+		return param.Names[0].Name
 	}
 
 	return "req" // default fallback
 }
 
+// requestParam returns the request parameter of a Reconcile method: the
+// second one, or the only one in the context-less Reconcile(req) of
+// controller-runtime before v0.7, as scaffolded by kubebuilder v2 and
+// operator-sdk's pkg/controller/<kind> layout. fn must have parameters.
+func requestParam(fn *ast.FuncDecl) *ast.Field {
+	if len(fn.Type.Params.List) == 1 {
+		return fn.Type.Params.List[0]
+	}
+	return fn.Type.Params.List[1]
+}
+
 // ExtractClientFieldName tries to find the client field name in the receiver type.
-// Common patterns: r.Client, c.client, reconciler.Client, etc.
+// Fields are candidates if their name contains "client" or, regardless of
+// name, if their type exposes a client (see isClientType).
 func ExtractClientFieldName(recvType *types.Struct) []string {
 	var candidates []string
 
@@ -224,8 +699,8 @@ func ExtractClientFieldName(recvType *types.Struct) []string {
 		field := recvType.Field(i)
 		fieldName := field.Name()
 
-		// Look for fields named "Client" or "client".
-		if strings.Contains(strings.ToLower(fieldName), "client") {
+		// Look for fields named "Client" or "client", or typed as one.
+		if strings.Contains(strings.ToLower(fieldName), "client") || isClientType(field.Type()) {
 			candidates = append(candidates, fieldName)
 		}
 	}
@@ -237,3 +712,48 @@ func ExtractClientFieldName(recvType *types.Struct) []string {
 
 	return candidates
 }
+
+// isClientType checks if t exposes a controller-runtime client: it has the
+// client.Reader methods (Get(ctx, key, obj, ...) and List), the client.Writer
+// methods (Create, Update, Delete, Patch), or a GetClient method like
+// manager.Manager.
+func isClientType(t types.Type) bool {
+	mset := types.NewMethodSet(t)
+	if _, ok := t.Underlying().(*types.Interface); !ok {
+		if _, ok := t.(*types.Pointer); !ok {
+			mset = types.NewMethodSet(types.NewPointer(t))
+		}
+	}
+
+	has := func(names ...string) bool {
+		for _, name := range names {
+			if mset.Lookup(nil, name) == nil {
+				return false
+			}
+		}
+		return true
+	}
+
+	if get := mset.Lookup(nil, "Get"); get != nil && has("List") {
+		if sig, ok := get.Type().(*types.Signature); ok && sig.Params().Len() >= 3 {
+			return true
+		}
+	}
+	return has("Create", "Update", "Delete", "Patch") || has("GetClient")
+}
+
+// receiverStruct returns the struct type of fn's receiver, if known.
+func receiverStruct(fn *ast.FuncDecl, pkg *packages.Package) *types.Struct {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 || pkg.TypesInfo == nil {
+		return nil
+	}
+	t := pkg.TypesInfo.TypeOf(fn.Recv.List[0].Type)
+	if t == nil {
+		return nil
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, _ := t.Underlying().(*types.Struct)
+	return st
+}
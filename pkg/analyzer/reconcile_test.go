@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const widgetController = `package controllers
+
+import "context"
+
+type Request struct{ Name string }
+type Result struct{}
+
+type WidgetReconciler struct{}
+
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	return Result{}, nil
+}
+`
+
+const fakeController = `package controllers
+
+import "context"
+
+type FakeReconciler struct{}
+
+func (r *FakeReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	return Result{}, nil
+}
+`
+
+func TestIncludeTests(t *testing.T) {
+	files := map[string]string{
+		"controllers/widget.go":      widgetController,
+		"controllers/widget_test.go": fakeController,
+	}
+	tests := []struct {
+		includeTests bool
+		want         []string
+	}{
+		{false, []string{"WidgetReconciler"}},
+		{true, []string{"WidgetReconciler", "FakeReconciler"}},
+	}
+	for _, tt := range tests {
+		a := NewAnalyzer(t.TempDir(), false)
+		a.IncludeTests = tt.includeTests
+		reconcilers, err := a.AnalyzeSource(files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]int)
+		for _, r := range reconcilers {
+			got[r.ReceiverType]++
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("IncludeTests=%t: found %v, want %v", tt.includeTests, got, tt.want)
+		}
+		for _, recv := range tt.want {
+			if got[recv] != 1 {
+				t.Errorf("IncludeTests=%t: found %s %d times, want once", tt.includeTests, recv, got[recv])
+			}
+		}
+	}
+}
+
+// The source declares controller-runtime's module itself, so the reconcile
+// package resolves without network access.
+var strictSignatureFiles = map[string]string{
+	"go.mod": "module sigs.k8s.io/controller-runtime\n\ngo 1.21\n",
+	"pkg/reconcile/reconcile.go": `package reconcile
+
+type Request struct{ Name string }
+type Result struct{ Requeue bool }
+`,
+	"controllers/real.go": `package controllers
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type RealReconciler struct{}
+
+func (r *RealReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, nil
+}
+`,
+	"controllers/lookalike.go": `package controllers
+
+import "context"
+
+type Request struct{ Name string }
+type Result struct{}
+
+type LookalikeReconciler struct{}
+
+func (r *LookalikeReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	return Result{}, nil
+}
+`,
+}
+
+func TestStrictSignature(t *testing.T) {
+	tests := []struct {
+		strict bool
+		want   []string
+	}{
+		{false, []string{"LookalikeReconciler", "RealReconciler"}},
+		{true, []string{"RealReconciler"}},
+	}
+	for _, tt := range tests {
+		a := NewAnalyzer(t.TempDir(), false)
+		a.StrictSignature = tt.strict
+		reconcilers, err := a.AnalyzeSource(strictSignatureFiles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range reconcilers {
+			got = append(got, r.ReceiverType)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("StrictSignature=%t: found %v, want %v", tt.strict, got, tt.want)
+		}
+	}
+}
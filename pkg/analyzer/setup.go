@@ -0,0 +1,425 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// DetectSetupPatterns analyzes a SetupWithManager function and returns
+// signals derived from the controller builder chain (.For, .Owns, .Watches)
+// and from low-level controller.New watch registrations (c.Watch).
+// Discovered types are available via PrimaryType and WatchedTypes, controller
+// options via ControllerOptions, change predicates via Predicates.
+func (pd *PatternDetector) DetectSetupPatterns(fn *ast.FuncDecl) []models.Signal {
+	var signals []models.Signal
+
+	if fn.Body == nil {
+		return signals
+	}
+
+	// Collect builder calls first; chained calls nest outermost-first, so
+	// order them by the position of the method name to follow source order.
+	var calls []*ast.CallExpr
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if _, ok := call.Fun.(*ast.SelectorExpr); ok && len(call.Args) > 0 {
+			calls = append(calls, call)
+		}
+		return true
+	})
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Fun.(*ast.SelectorExpr).Sel.Pos() < calls[j].Fun.(*ast.SelectorExpr).Sel.Pos()
+	})
+
+	for _, call := range calls {
+		sel := call.Fun.(*ast.SelectorExpr)
+		switch sel.Sel.Name {
+		case "For":
+			if t := objectTypeName(call.Args[0]); t != "" {
+				pd.primaryType = t
+				pd.addWatchedType(t)
+			}
+		case "Owns":
+			if t := objectTypeName(call.Args[0]); t != "" {
+				pd.addWatchedType(t)
+			}
+			signals = append(signals, models.Signal{
+				Type:        models.SignalOwnsResources,
+				Line:        pd.fset.Position(sel.Sel.Pos()).Line,
+				Score:       models.DefaultScore(models.SignalOwnsResources),
+				Snippet:     pd.extractBuilderSnippet(call),
+				Description: "Owns() in setup (owned objects enqueue their owner)",
+			})
+		case "Watches":
+			if t := objectTypeName(call.Args[0]); t != "" {
+				pd.addWatchedType(t)
+			}
+			if sig := pd.analyzeWatchHandler(call); sig.Type != "" {
+				signals = append(signals, sig)
+			}
+		case "Watch":
+			if sig := pd.analyzeControllerWatch(call); sig.Type != "" {
+				signals = append(signals, sig)
+			}
+		case "WithOptions", "New":
+			pd.recordControllerOptions(call)
+		}
+	}
+
+	return append(signals, pd.detectPredicates(fn.Body)...)
+}
+
+// predicatePkgPath is the import path of controller-runtime's event predicates.
+const predicatePkgPath = "sigs.k8s.io/controller-runtime/pkg/predicate"
+
+// changePredicates maps the built-in predicates that only pass updates
+// changing part of an object to the part they compare.
+var changePredicates = map[string]string{
+	"GenerationChangedPredicate":      "generation",
+	"LabelChangedPredicate":           "label",
+	"AnnotationChangedPredicate":      "annotation",
+	"ResourceVersionChangedPredicate": "resource version",
+}
+
+// detectPredicates reports each built-in change predicate instantiated in
+// the setup function, once per predicate, wherever it is passed: to
+// WithEventFilter, builder.WithPredicates, predicate.Or or c.Watch.
+func (pd *PatternDetector) detectPredicates(body *ast.BlockStmt) []models.Signal {
+	var signals []models.Signal
+	ast.Inspect(body, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		typ := lit.Type
+		// Strip type arguments of generic predicates, e.g. predicate.TypedLabelChangedPredicate[T].
+		if index, ok := typ.(*ast.IndexExpr); ok {
+			typ = index.X
+		}
+		sel, ok := typ.(*ast.SelectorExpr)
+		if !ok || !pd.isPkgSelector(sel, predicatePkgPath, "predicate") {
+			return true
+		}
+		name := strings.TrimPrefix(sel.Sel.Name, "Typed")
+		compared, ok := changePredicates[name]
+		if !ok || pd.hasPredicate(name) {
+			return true
+		}
+		pd.predicates = append(pd.predicates, name)
+		signals = append(signals, models.Signal{
+			Type:        models.SignalEventPredicate,
+			Line:        pd.fset.Position(lit.Pos()).Line,
+			Score:       models.DefaultScore(models.SignalEventPredicate),
+			Snippet:     pd.extractSnippet(lit),
+			Description: fmt.Sprintf("predicate.%s in setup (reconciles only on %s changes)", name, compared),
+		})
+		return true
+	})
+	return signals
+}
+
+// hasPredicate checks if a change predicate was already recorded.
+func (pd *PatternDetector) hasPredicate(name string) bool {
+	for _, p := range pd.predicates {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldIndexes returns the keys of the field indexes registered in the
+// setup function via mgr.GetFieldIndexer().IndexField(ctx, obj, key, fn).
+func (pd *PatternDetector) fieldIndexes(fn *ast.FuncDecl) map[string]bool {
+	if fn.Body == nil {
+		return nil
+	}
+	keys := make(map[string]bool)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 4 {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "IndexField" {
+			keys[pd.stringValue(call.Args[2])] = true
+		}
+		return true
+	})
+	return keys
+}
+
+// extractBuilderSnippet extracts only the method call of a builder chain
+// element, e.g. Owns(&v1.Foo{}) rather than the whole chain up to it.
+func (pd *PatternDetector) extractBuilderSnippet(call *ast.CallExpr) string {
+	sel := call.Fun.(*ast.SelectorExpr)
+	start := pd.fset.Position(sel.Sel.Pos()).Offset
+	end := pd.fset.Position(call.End()).Offset
+	if pd.fileData != nil && start >= 0 && end <= len(pd.fileData) && start < end {
+		return pd.truncateSnippet(string(pd.fileData[start:end]))
+	}
+	return pd.extractSnippet(call)
+}
+
+// analyzeWatchHandler classifies the event handler passed to a Watches call.
+func (pd *PatternDetector) analyzeWatchHandler(call *ast.CallExpr) models.Signal {
+	line := pd.fset.Position(call.Fun.(*ast.SelectorExpr).Sel.Pos()).Line
+
+	for _, arg := range call.Args[1:] {
+		switch handlerName(arg) {
+		case "EnqueueRequestForOwner", "EnqueueRequestForObject":
+			return models.Signal{
+				Type:        models.SignalWatchesWithHandler,
+				Line:        line,
+				Score:       models.DefaultScore(models.SignalWatchesWithHandler),
+				Snippet:     pd.extractBuilderSnippet(call),
+				Description: "Watches() enqueuing the owner or the object itself (edge-triggered)",
+			}
+		case "EnqueueRequestsFromMapFunc":
+			return models.Signal{
+				Type:        models.SignalWatchesMapFunc,
+				Line:        line,
+				Score:       models.DefaultScore(models.SignalWatchesMapFunc),
+				Snippet:     pd.extractBuilderSnippet(call),
+				Description: "Watches() with EnqueueRequestsFromMapFunc (fan-out on change)",
+			}
+		}
+	}
+
+	return models.Signal{}
+}
+
+// analyzeControllerWatch classifies a low-level c.Watch(source, handler)
+// registration. The handler may also be passed inside the source, as in
+// source.Kind(cache, obj, handler). EnqueueRequestForObject on a type makes
+// it the primary type, like .For(); EnqueueRequestForOwner is equivalent to
+// .Owns().
+func (pd *PatternDetector) analyzeControllerWatch(call *ast.CallExpr) models.Signal {
+	line := pd.fset.Position(call.Fun.(*ast.SelectorExpr).Sel.Pos()).Line
+	t := objectTypeName(call.Args[0])
+
+	handlers := call.Args[1:]
+	if src, ok := call.Args[0].(*ast.CallExpr); ok {
+		handlers = append(handlers, src.Args...)
+	}
+
+	for _, arg := range handlers {
+		switch handlerName(arg) {
+		case "EnqueueRequestForObject":
+			if t != "" {
+				if pd.primaryType == "" {
+					pd.primaryType = t
+				}
+				pd.addWatchedType(t)
+			}
+			return models.Signal{}
+		case "EnqueueRequestForOwner":
+			if t != "" {
+				pd.addWatchedType(t)
+			}
+			return models.Signal{
+				Type:        models.SignalOwnsResources,
+				Line:        line,
+				Score:       models.DefaultScore(models.SignalOwnsResources),
+				Snippet:     pd.extractBuilderSnippet(call),
+				Description: "Watch() with EnqueueRequestForOwner (owned objects enqueue their owner)",
+			}
+		case "EnqueueRequestsFromMapFunc":
+			if t != "" {
+				pd.addWatchedType(t)
+			}
+			return models.Signal{
+				Type:        models.SignalWatchesMapFunc,
+				Line:        line,
+				Score:       models.DefaultScore(models.SignalWatchesMapFunc),
+				Snippet:     pd.extractBuilderSnippet(call),
+				Description: "Watch() with EnqueueRequestsFromMapFunc (fan-out on change)",
+			}
+		}
+	}
+
+	if t != "" {
+		pd.addWatchedType(t)
+	}
+	return models.Signal{}
+}
+
+// handlerName returns the handler constructor name from expressions like
+// handler.EnqueueRequestForOwner(...) or &handler.EnqueueRequestForObject{}.
+func handlerName(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	var fun ast.Expr
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		fun = e.Fun
+	case *ast.CompositeLit:
+		fun = e.Type
+	default:
+		return ""
+	}
+
+	// Strip type arguments of generic handlers, e.g. handler.TypedEnqueueRequestForOwner[T].
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+
+	// Typed handlers behave like their untyped counterparts.
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return strings.TrimPrefix(f.Name, "Typed")
+	case *ast.SelectorExpr:
+		return strings.TrimPrefix(f.Sel.Name, "Typed")
+	}
+	return ""
+}
+
+// objectTypeName extracts the object type from a builder argument such as
+// &v1.Foo{}, source.Kind(cache, &v1.Foo{}) or &source.Kind{Type: &v1.Foo{}}.
+func objectTypeName(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		if sel, ok := e.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Kind" {
+			// Old-style &source.Kind{Type: &v1.Foo{}}.
+			for _, elt := range e.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					return objectTypeName(kv.Value)
+				}
+			}
+			return ""
+		}
+		return types.ExprString(e.Type)
+	case *ast.CallExpr:
+		// New-style source.Kind(cache, &v1.Foo{}, ...).
+		for _, arg := range e.Args {
+			if t := objectTypeName(arg); t != "" {
+				return t
+			}
+		}
+	}
+	return ""
+}
+
+// recordControllerOptions records the concurrency and rate limiting set in a
+// controller.Options literal passed to .WithOptions() or controller.New().
+func (pd *PatternDetector) recordControllerOptions(call *ast.CallExpr) {
+	for _, arg := range call.Args {
+		if unary, ok := arg.(*ast.UnaryExpr); ok {
+			arg = unary.X
+		}
+		lit, ok := arg.(*ast.CompositeLit)
+		if !ok || !isOptionsType(lit.Type) {
+			continue
+		}
+
+		opts := &models.ControllerOptions{}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			switch key.Name {
+			case "MaxConcurrentReconciles":
+				opts.MaxConcurrentReconciles = types.ExprString(kv.Value)
+				if n, ok := pd.constInt(kv.Value); ok {
+					opts.MaxConcurrentReconciles = strconv.FormatInt(n, 10)
+				}
+			case "RateLimiter":
+				opts.RateLimiter = rateLimiterName(kv.Value)
+			}
+		}
+		if opts.MaxConcurrentReconciles != "" || opts.RateLimiter != "" {
+			pd.controllerOptions = opts
+		}
+	}
+}
+
+// isOptionsType checks if a composite literal type is controller.Options or
+// its generic form controller.TypedOptions[T].
+func isOptionsType(expr ast.Expr) bool {
+	if index, ok := expr.(*ast.IndexExpr); ok {
+		expr = index.X
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && (sel.Sel.Name == "Options" || sel.Sel.Name == "TypedOptions")
+}
+
+// rateLimiterName names a rate limiter by its constructor, e.g.
+// workqueue.NewItemExponentialFailureRateLimiter, or by its expression when
+// it is not constructed in place.
+func rateLimiterName(expr ast.Expr) string {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		fun := call.Fun
+		if index, ok := fun.(*ast.IndexExpr); ok {
+			fun = index.X
+		}
+		return types.ExprString(fun)
+	}
+	return types.ExprString(expr)
+}
+
+// constInt evaluates expr as a constant integer if possible.
+func (pd *PatternDetector) constInt(expr ast.Expr) (int64, bool) {
+	if pd.pkg != nil && pd.pkg.TypesInfo != nil {
+		if tv, ok := pd.pkg.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.Int {
+			return constant.Int64Val(tv.Value)
+		}
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.INT {
+		n, err := strconv.ParseInt(lit.Value, 0, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// ControllerOptions returns the controller options set in the analyzed
+// setup function, or nil if none were found.
+func (pd *PatternDetector) ControllerOptions() *models.ControllerOptions {
+	return pd.controllerOptions
+}
+
+// Predicates returns the built-in change predicates used in the analyzed
+// setup function, in source order.
+func (pd *PatternDetector) Predicates() []string {
+	return pd.predicates
+}
+
+// addWatchedType records a watched type once.
+func (pd *PatternDetector) addWatchedType(t string) {
+	for _, existing := range pd.watchedTypes {
+		if existing == t {
+			return
+		}
+	}
+	pd.watchedTypes = append(pd.watchedTypes, t)
+}
+
+// PrimaryType returns the type passed to .For() in the analyzed setup function.
+func (pd *PatternDetector) PrimaryType() string {
+	return pd.primaryType
+}
+
+// WatchedTypes returns the types passed to .For(), .Owns() and .Watches().
+func (pd *PatternDetector) WatchedTypes() []string {
+	return pd.watchedTypes
+}
@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"go/parser"
+	"reflect"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestHandlerName(t *testing.T) {
+	tests := []struct {
+		expr, want string
+	}{
+		{"&handler.EnqueueRequestForObject{}", "EnqueueRequestForObject"},
+		{"handler.EnqueueRequestForOwner(scheme, mapper, &v1.Foo{})", "EnqueueRequestForOwner"},
+		{"handler.EnqueueRequestsFromMapFunc(r.mapFn)", "EnqueueRequestsFromMapFunc"},
+		{"handler.TypedEnqueueRequestForOwner[*v1.Foo](scheme, mapper, &v1.Foo{})", "EnqueueRequestForOwner"},
+		{"EnqueueRequestForObject{}", "EnqueueRequestForObject"},
+		{"r.handler", ""},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := handlerName(expr); got != tt.want {
+			t.Errorf("handlerName(%s) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestObjectTypeName(t *testing.T) {
+	tests := []struct {
+		expr, want string
+	}{
+		{"&v1.Deployment{}", "v1.Deployment"},
+		{"&source.Kind{Type: &corev1.Secret{}}", "corev1.Secret"},
+		{"source.Kind(mgr.GetCache(), &corev1.Secret{}, h)", "corev1.Secret"},
+		{"obj", ""},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := objectTypeName(expr); got != tt.want {
+			t.Errorf("objectTypeName(%s) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+const setupController = `package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{}
+
+type Widget struct{}
+type Secret struct{}
+type Deployment struct{}
+
+type Manager interface{}
+type Builder struct{}
+
+func NewControllerManagedBy(mgr Manager) *Builder                                       { return &Builder{} }
+func (b *Builder) For(obj interface{}, opts ...interface{}) *Builder                    { return b }
+func (b *Builder) Owns(obj interface{}, opts ...interface{}) *Builder                   { return b }
+func (b *Builder) Watches(obj interface{}, h interface{}, opts ...interface{}) *Builder { return b }
+func (b *Builder) Complete(r interface{}) error                                         { return nil }
+
+type WidgetReconciler struct{}
+
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	return Result{}, nil
+}
+
+func (r *WidgetReconciler) secretToWidgets(ctx context.Context, obj interface{}) []Request {
+	return nil
+}
+
+func (r *WidgetReconciler) SetupWithManager(mgr Manager) error {
+	return NewControllerManagedBy(mgr).
+		For(&Widget{}).
+		Owns(&Deployment{}).
+		Watches(&Secret{}, handler.EnqueueRequestsFromMapFunc(r.secretToWidgets)).
+		Watches(&Deployment{}, handler.EnqueueRequestForOwner(nil, nil, &Widget{})).
+		Complete(r)
+}
+`
+
+func TestSetupPatterns(t *testing.T) {
+	r := analyzeOne(t, setupController)
+	if r.PrimaryType != "Widget" {
+		t.Errorf("PrimaryType = %q, want Widget", r.PrimaryType)
+	}
+	if want := []string{"Widget", "Deployment", "Secret"}; !reflect.DeepEqual(r.WatchedTypes, want) {
+		t.Errorf("WatchedTypes = %v, want %v (deduplicated, in setup order)", r.WatchedTypes, want)
+	}
+	mapFuncs := 0
+	for _, sig := range r.Signals {
+		if sig.Type == models.SignalWatchesMapFunc {
+			mapFuncs++
+		}
+	}
+	if mapFuncs != 1 {
+		t.Errorf("found %d %s signals in %v, want 1", mapFuncs, models.SignalWatchesMapFunc, signalTypes(r))
+	}
+}
@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// SourceModule is the module path AnalyzeSource declares when the given
+// files contain no go.mod.
+const SourceModule = "example.com/source"
+
+// AnalyzeSource analyzes in-memory Go source files, keyed by slash-separated
+// path relative to the module root (e.g. "controllers/foo.go"), and returns
+// all found reconcilers. The files themselves are not written to disk: an
+// empty scratch root is created, and removed on return, and the files are
+// served from a packages.Config overlay below it, including to the go.mod
+// and ignore-file lookups of the loader. Imports that cannot be resolved
+// leave the affected packages with name-based heuristics only, as with any
+// repository.
+func (a *Analyzer) AnalyzeSource(files map[string]string) ([]models.Reconciler, error) {
+	root, err := os.MkdirTemp("", "survey-source-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source root: %w", err)
+	}
+	defer os.RemoveAll(root)
+
+	overlay := make(map[string][]byte, len(files)+1)
+	for name, src := range files {
+		overlay[filepath.Join(root, filepath.FromSlash(name))] = []byte(src)
+	}
+	if _, ok := files["go.mod"]; !ok {
+		overlay[filepath.Join(root, "go.mod")] = []byte("module " + SourceModule + "\n\ngo 1.21\n")
+	}
+
+	// Work on a copy so concurrent repository analysis is unaffected.
+	src := *a
+	src.overlay = overlay
+
+	return src.AnalyzeRepo(models.Repository{
+		URL:       SourceModule,
+		Owner:     "source",
+		Name:      "source",
+		Source:    "source",
+		LocalPath: root,
+	})
+}
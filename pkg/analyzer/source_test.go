@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const listController = `package controllers
+
+import "context"
+
+type Request struct{ Name string }
+type Result struct{}
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+}
+
+type PodList struct{}
+
+type PodReconciler struct{ Client Client }
+
+func (r *PodReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var pods PodList
+	return Result{}, r.Client.List(ctx, &pods)
+}
+`
+
+func TestAnalyzeSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		pkg   string
+	}{
+		{
+			name:  "default module",
+			files: map[string]string{"controllers/pod.go": listController},
+			pkg:   SourceModule + "/controllers",
+		},
+		{
+			name: "own go.mod",
+			files: map[string]string{
+				"go.mod":             "module example.com/own\n\ngo 1.21\n",
+				"controllers/pod.go": listController,
+			},
+			pkg: "example.com/own/controllers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyzer(t.TempDir(), false)
+			reconcilers, err := a.AnalyzeSource(tt.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reconcilers) != 1 {
+				t.Fatalf("found %d reconcilers, want 1", len(reconcilers))
+			}
+			r := reconcilers[0]
+			if r.ReceiverType != "PodReconciler" || r.ReceiverPkg != tt.pkg || r.File != "controllers/pod.go" {
+				t.Errorf("reconciler = %s.%s in %s, want %s.PodReconciler in controllers/pod.go", r.ReceiverPkg, r.ReceiverType, r.File, tt.pkg)
+			}
+			// Snippets are cut from the overlay, not from disk.
+			if len(r.Signals) == 0 {
+				t.Fatal("no signals detected")
+			}
+			for _, sig := range r.Signals {
+				if !strings.Contains(sig.Snippet, "List") {
+					t.Errorf("signal %s snippet = %q, want the List call", sig.Type, sig.Snippet)
+				}
+			}
+		})
+	}
+}
+
+// TestOverlayModule checks that the go.mod lookups of the loader see a go.mod
+// served only from the overlay, as AnalyzeSource does.
+func TestOverlayModule(t *testing.T) {
+	root := t.TempDir()
+	goMod := "module example.com/op\n\nrequire sigs.k8s.io/controller-runtime v0.19.0\n"
+	a := &Analyzer{overlay: map[string][]byte{
+		filepath.Join(root, "go.mod"): []byte(goMod),
+		filepath.Join(root, "go.sum"): []byte("sum\n"),
+	}}
+
+	modfile, cleanup, err := a.scratchModfile(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	if modfile == "" {
+		t.Fatal("scratchModfile() = \"\", want a copy of the overlay go.mod")
+	}
+	for name, want := range map[string]string{"go.mod": goMod, "go.sum": "sum\n"} {
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(modfile), name))
+		if err != nil || string(data) != want {
+			t.Errorf("scratch %s = %q, %v, want %q", name, data, err, want)
+		}
+	}
+
+	modPath, version, err := a.readModuleInfo(root)
+	if err != nil || modPath != "example.com/op" || version != "v0.19.0" {
+		t.Errorf("readModuleInfo() = %q, %q, %v, want example.com/op, v0.19.0", modPath, version, err)
+	}
+}
+
+func TestExplainTrace(t *testing.T) {
+	for _, explain := range []bool{false, true} {
+		a := NewAnalyzer(t.TempDir(), false)
+		a.Explain = explain
+		reconcilers, err := a.AnalyzeSource(map[string]string{"controllers/pod.go": listController})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reconcilers) != 1 {
+			t.Fatalf("found %d reconcilers, want 1", len(reconcilers))
+		}
+		r := reconcilers[0]
+		if !explain {
+			if r.Trace != nil {
+				t.Errorf("Explain=false: got trace %+v, want none", r.Trace)
+			}
+			continue
+		}
+		if r.Trace == nil {
+			t.Fatal("Explain=true: no trace")
+		}
+		if r.Trace.Score != r.Score || r.Trace.Classification != r.Classification || len(r.Trace.Steps) != len(r.Signals) {
+			t.Errorf("trace %+v disagrees with score %d (%s) from %d signals", r.Trace, r.Score, r.Classification, len(r.Signals))
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Fixture: a rendered manifest set applied object by object.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type Object interface{ DeepCopyObject() Object }
+
+type Addon struct{ Version string }
+
+func RenderManifests(a *Addon) []Object { return nil }
+
+type AddonReconciler struct{ Client Client }
+
+func (r *AddonReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var addon Addon
+	if err := r.Client.Get(ctx, req.NamespacedName, &addon); err != nil {
+		return Result{}, err
+	}
+	for _, obj := range RenderManifests(&addon) {
+		if err := r.Client.Update(ctx, obj); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,42 @@
+// Fixture: a reconciler that fetches its object, then reads three other
+// kinds to assemble its view of the cluster.
+package fixture
+
+import "context"
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Key struct{ Namespace, Name string }
+
+type Client interface {
+	Get(ctx context.Context, key Key, obj interface{}) error
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+}
+
+type Gateway struct{ Class string }
+type GatewayClass struct{ Controller string }
+type RouteList struct{ Items []Gateway }
+type ServiceList struct{ Items []Gateway }
+
+type GatewayReconciler struct{ Client Client }
+
+func (r *GatewayReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var gw Gateway
+	if err := r.Client.Get(ctx, Key{Namespace: req.Namespace, Name: req.Name}, &gw); err != nil {
+		return Result{}, err
+	}
+	var class GatewayClass
+	if err := r.Client.Get(ctx, Key{Name: gw.Class}, &class); err != nil {
+		return Result{}, err
+	}
+	var routes RouteList
+	if err := r.Client.List(ctx, &routes); err != nil {
+		return Result{}, err
+	}
+	var services ServiceList
+	if err := r.Client.List(ctx, &services, req.Namespace); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
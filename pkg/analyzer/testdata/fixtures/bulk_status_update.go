@@ -0,0 +1,56 @@
+// Fixture: a reconciler that lists all widgets and updates the status of
+// every item, ignoring which object the request names, and one updating the
+// status of the request's own children only.
+package fixture
+
+import "context"
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type StatusWriter interface {
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+	Status() StatusWriter
+}
+
+type MatchingLabels map[string]string
+
+type WidgetStatus struct{ Ready bool }
+type Widget struct{ Status WidgetStatus }
+type WidgetList struct{ Items []Widget }
+
+type WidgetStatusReconciler struct{ Client Client }
+
+func (r *WidgetStatusReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var widgets WidgetList
+	if err := r.Client.List(ctx, &widgets); err != nil {
+		return Result{}, err
+	}
+	for i := range widgets.Items {
+		widgets.Items[i].Status.Ready = true
+		if err := r.Client.Status().Update(ctx, &widgets.Items[i]); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
+
+type ChildStatusReconciler struct{ Client Client }
+
+func (r *ChildStatusReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var widgets WidgetList
+	if err := r.Client.List(ctx, &widgets, MatchingLabels{"parent": req.Name}); err != nil {
+		return Result{}, err
+	}
+	for i := range widgets.Items {
+		widgets.Items[i].Status.Ready = true
+		if err := r.Client.Status().Update(ctx, &widgets.Items[i]); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
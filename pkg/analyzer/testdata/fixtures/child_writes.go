@@ -0,0 +1,62 @@
+// Fixture: writes through the controller-runtime helpers: a status
+// condition, an owner reference on a child, server-side apply, and an
+// update retried on conflict.
+package fixture
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+	Patch(ctx context.Context, obj interface{}, patch interface{}, opts ...interface{}) error
+}
+
+type Condition struct{ Type, Status string }
+
+type Database struct {
+	Name       string
+	Conditions []Condition
+	Replicas   int
+}
+
+type StatefulSet struct{ Name string }
+
+type DatabaseReconciler struct {
+	Client Client
+	Scheme interface{}
+}
+
+func (r *DatabaseReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var db Database
+	if err := r.Client.Get(ctx, req.NamespacedName, &db); err != nil {
+		return Result{}, err
+	}
+
+	sts := &StatefulSet{Name: db.Name}
+	if err := controllerutil.SetControllerReference(&db, sts, r.Scheme); err != nil {
+		return Result{}, err
+	}
+	if err := r.Client.Patch(ctx, sts, client.Apply, client.FieldOwner("database-controller")); err != nil {
+		return Result{}, err
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Client.Get(ctx, req.NamespacedName, &db); err != nil {
+			return err
+		}
+		meta.SetStatusCondition(&db.Conditions, Condition{Type: "Ready", Status: "True"})
+		return r.Client.Update(ctx, &db)
+	})
+	return Result{}, err
+}
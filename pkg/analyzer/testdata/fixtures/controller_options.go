@@ -0,0 +1,41 @@
+// Fixture: a controller raising MaxConcurrentReconciles and configuring a
+// workqueue rate limiter in its setup.
+package fixture
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ RequeueAfter time.Duration }
+
+type Widget struct{ Name string }
+
+type Manager interface{}
+
+type Builder struct{}
+
+func NewControllerManagedBy(mgr Manager) *Builder               { return &Builder{} }
+func (b *Builder) For(obj interface{}) *Builder                 { return b }
+func (b *Builder) WithOptions(opts controller.Options) *Builder { return b }
+func (b *Builder) Complete(r interface{}) error                 { return nil }
+
+type WidgetReconciler struct{}
+
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	return Result{RequeueAfter: 30 * time.Second}, nil
+}
+
+func (r *WidgetReconciler) SetupWithManager(mgr Manager) error {
+	return NewControllerManagedBy(mgr).
+		For(&Widget{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 16,
+			RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Minute),
+		}).
+		Complete(r)
+}
@@ -0,0 +1,40 @@
+// Fixture: a CreateOrPatch upsert whose mutate function lists secrets,
+// redundantly updates the upserted object and creates an unrelated one.
+package fixture
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+	Create(ctx context.Context, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type Deployment struct{ Replicas int }
+type ConfigMap struct{ Name string }
+type SecretList struct{ Items []ConfigMap }
+
+type DeploymentReconciler struct{ Client Client }
+
+func (r *DeploymentReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	deploy := &Deployment{}
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, deploy, func() error {
+		var secrets SecretList
+		if err := r.Client.List(ctx, &secrets); err != nil {
+			return err
+		}
+		deploy.Replicas = len(secrets.Items)
+		if err := r.Client.Update(ctx, deploy); err != nil {
+			return err
+		}
+		return r.Client.Create(ctx, &ConfigMap{Name: req.Name + "-config"})
+	})
+	return Result{}, err
+}
@@ -0,0 +1,57 @@
+// Fixture: bulk DeleteAllOf calls scoped the ways a List can be: not at all,
+// by the request's namespace, by a hardcoded namespace and by a namespace
+// from configuration.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	DeleteAllOf(ctx context.Context, obj interface{}, opts ...interface{}) error
+}
+
+func InNamespace(ns string) interface{} { return ns }
+
+type Job struct{ Name string }
+
+type PurgeReconciler struct{ Client Client }
+
+func (r *PurgeReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	if err := r.Client.DeleteAllOf(ctx, &Job{}); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+type NamespacePurgeReconciler struct{ Client Client }
+
+func (r *NamespacePurgeReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	if err := r.Client.DeleteAllOf(ctx, &Job{}, InNamespace(req.Namespace)); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+type SystemPurgeReconciler struct{ Client Client }
+
+func (r *SystemPurgeReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	if err := r.Client.DeleteAllOf(ctx, &Job{}, InNamespace("kube-system")); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+type ConfiguredPurgeReconciler struct {
+	Client    Client
+	Namespace string
+}
+
+func (r *ConfiguredPurgeReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	if err := r.Client.DeleteAllOf(ctx, &Job{}, InNamespace(r.Namespace)); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
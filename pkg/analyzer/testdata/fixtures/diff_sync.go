@@ -0,0 +1,64 @@
+// Fixture: the diff-then-sync shape: list the children, index them by name,
+// write every desired child, then delete the listed ones no longer desired.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+	Create(ctx context.Context, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+	Delete(ctx context.Context, obj interface{}) error
+}
+
+type Route struct{ Name string }
+type RouteList struct{ Items []Route }
+type Gateway struct{ Routes []string }
+
+type GatewayReconciler struct{ Client Client }
+
+func (r *GatewayReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var gw Gateway
+	if err := r.Client.Get(ctx, req.NamespacedName, &gw); err != nil {
+		return Result{}, err
+	}
+
+	var routes RouteList
+	if err := r.Client.List(ctx, &routes); err != nil {
+		return Result{}, err
+	}
+	existing := make(map[string]Route)
+	for _, route := range routes.Items {
+		existing[route.Name] = route
+	}
+
+	desired := make(map[string]bool)
+	for _, name := range gw.Routes {
+		desired[name] = true
+		route := Route{Name: name}
+		if _, ok := existing[name]; ok {
+			if err := r.Client.Update(ctx, &route); err != nil {
+				return Result{}, err
+			}
+			continue
+		}
+		if err := r.Client.Create(ctx, &route); err != nil {
+			return Result{}, err
+		}
+	}
+
+	for _, route := range routes.Items {
+		if desired[route.Name] {
+			continue
+		}
+		if err := r.Client.Delete(ctx, &route); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,64 @@
+// Fixture: drift checks comparing desired and actual specs, repeated within
+// one function, and a comparison of labels only, which is not a drift check.
+package fixture
+
+import (
+	"context"
+	"reflect"
+)
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type ObjectMeta struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+type DeploymentSpec struct{ Replicas int32 }
+type Deployment struct {
+	ObjectMeta
+	Spec DeploymentSpec
+}
+
+type DriftReconciler struct{ Client Client }
+
+func (r *DriftReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var found Deployment
+	if err := r.Client.Get(ctx, req.NamespacedName, &found); err != nil {
+		return Result{}, err
+	}
+	desired := Deployment{Spec: DeploymentSpec{Replicas: 3}}
+	if !reflect.DeepEqual(found.Spec, desired.Spec) {
+		found.Spec = desired.Spec
+	}
+	if !reflect.DeepEqual(found.Spec, desired.Spec) || !reflect.DeepEqual(found.Labels, desired.Labels) {
+		if err := r.Client.Update(ctx, &found); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
+
+type LabelReconciler struct{ Client Client }
+
+func (r *LabelReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var found Deployment
+	if err := r.Client.Get(ctx, req.NamespacedName, &found); err != nil {
+		return Result{}, err
+	}
+	want := map[string]string{"app": req.Name}
+	if !reflect.DeepEqual(found.Labels, want) || !reflect.DeepEqual(found.Annotations, want) {
+		found.Labels = want
+		if err := r.Client.Update(ctx, &found); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
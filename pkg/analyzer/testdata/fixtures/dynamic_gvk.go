@@ -0,0 +1,34 @@
+// Fixture: a generic applier resolving each object's kind and REST mapping
+// at runtime before writing it.
+package fixture
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Create(ctx context.Context, obj interface{}) error
+}
+
+type ApplierReconciler struct {
+	Client  Client
+	Mapper  meta.RESTMapper
+	Objects []interface{}
+}
+
+func (r *ApplierReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	for _, obj := range r.Objects {
+		if _, err := r.Mapper.RESTMapping("Deployment", "v1"); err != nil {
+			return Result{}, err
+		}
+		if err := r.Client.Create(ctx, obj); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
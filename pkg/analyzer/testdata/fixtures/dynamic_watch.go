@@ -0,0 +1,40 @@
+// Fixture: a cluster-api style external tracker watching a referenced object.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+}
+
+type ObjectTracker struct{}
+
+func (o *ObjectTracker) Watch(log interface{}, obj interface{}, handler interface{}) error {
+	return nil
+}
+
+type Machine struct{ InfrastructureRef NamespacedName }
+
+type MachineReconciler struct {
+	Client          Client
+	externalTracker ObjectTracker
+}
+
+func (r *MachineReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var m Machine
+	if err := r.Client.Get(ctx, req.NamespacedName, &m); err != nil {
+		return Result{}, err
+	}
+	var infra struct{}
+	if err := r.Client.Get(ctx, m.InfrastructureRef, &infra); err != nil {
+		return Result{}, err
+	}
+	if err := r.externalTracker.Watch(nil, &infra, nil); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,45 @@
+// Fixture: a controller filtering its events through several built-in
+// change predicates, one of them listed twice.
+package fixture
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Widget struct{ Name string }
+type ConfigMap struct{ Name string }
+
+type Manager interface{}
+
+type Builder struct{}
+
+func NewControllerManagedBy(mgr Manager) *Builder                     { return &Builder{} }
+func (b *Builder) For(obj interface{}, opts ...interface{}) *Builder  { return b }
+func (b *Builder) Owns(obj interface{}, opts ...interface{}) *Builder { return b }
+func (b *Builder) WithEventFilter(p interface{}) *Builder             { return b }
+func (b *Builder) Complete(r interface{}) error                       { return nil }
+
+func WithPredicates(predicates ...interface{}) interface{} { return nil }
+
+type WidgetReconciler struct{}
+
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	return Result{}, nil
+}
+
+func (r *WidgetReconciler) SetupWithManager(mgr Manager) error {
+	return NewControllerManagedBy(mgr).
+		For(&Widget{}, WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Owns(&ConfigMap{}, WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		WithEventFilter(predicate.LabelChangedPredicate{}).
+		Complete(r)
+}
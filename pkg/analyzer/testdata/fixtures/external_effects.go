@@ -0,0 +1,49 @@
+// Fixture: a reconciler that shells out to helm and notifies a webhook,
+// work the client read/write signals cannot see.
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os/exec"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Key struct{ Namespace, Name string }
+
+type Client interface {
+	Get(ctx context.Context, key Key, obj interface{}) error
+}
+
+type Release struct{ Chart string }
+
+type ReleaseReconciler struct {
+	Client     Client
+	HTTPClient *http.Client
+	WebhookURL string
+}
+
+func (r *ReleaseReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var release Release
+	if err := r.Client.Get(ctx, Key{Namespace: req.Namespace, Name: req.Name}, &release); err != nil {
+		return Result{}, err
+	}
+
+	cmd := exec.CommandContext(ctx, "helm", "upgrade", "--install", req.Name, release.Chart, "-n", req.Namespace)
+	if err := cmd.Run(); err != nil {
+		return Result{}, err
+	}
+
+	notify, err := http.NewRequestWithContext(ctx, http.MethodPost, r.WebhookURL, bytes.NewBufferString(req.Name))
+	if err != nil {
+		return Result{}, err
+	}
+	resp, err := r.HTTPClient.Do(notify)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{}, resp.Body.Close()
+}
@@ -0,0 +1,60 @@
+// Fixture: a controller registering a field index on its children's owner
+// in setup and listing the request's children by it.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+}
+
+type MatchingFields map[string]string
+
+func InNamespace(ns string) interface{} { return ns }
+
+type CronJob struct{ Name string }
+type Job struct{ Owner string }
+type JobList struct{ Items []Job }
+
+type FieldIndexer interface {
+	IndexField(ctx context.Context, obj interface{}, field string, extract func(interface{}) []string) error
+}
+
+type Manager interface {
+	GetFieldIndexer() FieldIndexer
+}
+
+type Builder struct{}
+
+func NewControllerManagedBy(mgr Manager) *Builder                     { return &Builder{} }
+func (b *Builder) For(obj interface{}, opts ...interface{}) *Builder  { return b }
+func (b *Builder) Owns(obj interface{}, opts ...interface{}) *Builder { return b }
+func (b *Builder) Complete(r interface{}) error                       { return nil }
+
+const jobOwnerKey = ".metadata.controller"
+
+type CronJobReconciler struct{ Client Client }
+
+func (r *CronJobReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var jobs JobList
+	if err := r.Client.List(ctx, &jobs, InNamespace(req.Namespace), MatchingFields{jobOwnerKey: req.Name}); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+func (r *CronJobReconciler) SetupWithManager(mgr Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &Job{}, jobOwnerKey, func(obj interface{}) []string {
+		return []string{obj.(*Job).Owner}
+	}); err != nil {
+		return err
+	}
+	return NewControllerManagedBy(mgr).
+		For(&CronJob{}).
+		Owns(&Job{}).
+		Complete(r)
+}
@@ -0,0 +1,32 @@
+// Fixture: the textbook edge-triggered shape; Get by request key, ignore
+// NotFound, update the object.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+}
+
+func IsNotFound(err error) bool { return false }
+
+type Widget struct{ Replicas int }
+
+type WidgetReconciler struct{ Client Client }
+
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var w Widget
+	if err := r.Client.Get(ctx, req.NamespacedName, &w); err != nil {
+		if IsNotFound(err) {
+			return Result{}, nil
+		}
+		return Result{}, err
+	}
+	w.Replicas = 3
+	return Result{}, r.Client.Update(ctx, &w)
+}
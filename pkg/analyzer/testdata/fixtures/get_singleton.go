@@ -0,0 +1,35 @@
+// Fixture: a cluster-scoped singleton config read by a constant key,
+// alongside a traversal to an object named by a spec field.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+}
+
+type ClusterConfig struct{ Proxy string }
+
+type Gateway struct{ Spec struct{ ClassName string } }
+
+type GatewayReconciler struct{ Client Client }
+
+func (r *GatewayReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var gw Gateway
+	if err := r.Client.Get(ctx, req.NamespacedName, &gw); err != nil {
+		return Result{}, err
+	}
+	var cfg ClusterConfig
+	if err := r.Client.Get(ctx, NamespacedName{Name: "cluster"}, &cfg); err != nil {
+		return Result{}, err
+	}
+	var class struct{}
+	if err := r.Client.Get(ctx, NamespacedName{Name: gw.Spec.ClassName}, &class); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
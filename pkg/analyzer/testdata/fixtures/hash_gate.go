@@ -0,0 +1,52 @@
+// Fixture: a reconciler that hashes the spec and skips the update while
+// the hash stored in an annotation still matches.
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Key struct{ Namespace, Name string }
+
+type Client interface {
+	Get(ctx context.Context, key Key, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type ObjectMeta struct{ Annotations map[string]string }
+type AppSpec struct{ Image string }
+type App struct {
+	ObjectMeta
+	Spec AppSpec
+}
+
+const specHashAnnotation = "example.com/spec-hash"
+
+type AppReconciler struct{ Client Client }
+
+func (r *AppReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var app App
+	if err := r.Client.Get(ctx, Key{Namespace: req.Namespace, Name: req.Name}, &app); err != nil {
+		return Result{}, err
+	}
+
+	data, err := json.Marshal(app.Spec)
+	if err != nil {
+		return Result{}, err
+	}
+	hasher := fnv.New32a()
+	hasher.Write(data)
+	hash := fmt.Sprintf("%x", hasher.Sum32())
+
+	if app.Annotations[specHashAnnotation] == hash {
+		return Result{}, nil
+	}
+	app.Annotations[specHashAnnotation] = hash
+	return Result{}, r.Client.Update(ctx, &app)
+}
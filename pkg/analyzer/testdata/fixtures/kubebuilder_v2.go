@@ -0,0 +1,89 @@
+// Fixture: a kubebuilder v1 / operator-sdk style package with two
+// controllers, each registered through Add(mgr) -> add(mgr, newReconciler(mgr))
+// with controller.New and c.Watch, and context-less Reconcile(req) methods.
+package fixture
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+}
+
+type Manager interface {
+	GetClient() Client
+}
+
+type Memcached struct{ Name string }
+type Pod struct{ Name string }
+type PodList struct{ Items []Pod }
+
+// Memcached controller.
+
+func AddMemcached(mgr Manager) error {
+	return addMemcached(mgr, newMemcachedReconciler(mgr))
+}
+
+func newMemcachedReconciler(mgr Manager) *ReconcileMemcached {
+	return &ReconcileMemcached{client: mgr.GetClient()}
+}
+
+func addMemcached(mgr Manager, r *ReconcileMemcached) error {
+	c, err := controller.New("memcached-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &Memcached{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	return c.Watch(&source.Kind{Type: &Pod{}}, &handler.EnqueueRequestForOwner{OwnerType: &Memcached{}})
+}
+
+type ReconcileMemcached struct{ client Client }
+
+func (r *ReconcileMemcached) Reconcile(req Request) (Result, error) {
+	var memcached Memcached
+	if err := r.client.Get(context.TODO(), req.NamespacedName, &memcached); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+// Pod sweeper controller, registered through a local variable.
+
+func AddSweeper(mgr Manager) error {
+	r := newSweeper(mgr)
+	return addSweeper(mgr, r)
+}
+
+func newSweeper(mgr Manager) *ReconcileSweeper {
+	return &ReconcileSweeper{client: mgr.GetClient()}
+}
+
+func addSweeper(mgr Manager, r *ReconcileSweeper) error {
+	c, err := controller.New("sweeper-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 4})
+	if err != nil {
+		return err
+	}
+	return c.Watch(&source.Kind{Type: &Pod{}}, &handler.EnqueueRequestsFromMapFunc{})
+}
+
+type ReconcileSweeper struct{ client Client }
+
+func (r *ReconcileSweeper) Reconcile(req Request) (Result, error) {
+	var pods PodList
+	if err := r.client.List(context.TODO(), &pods); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
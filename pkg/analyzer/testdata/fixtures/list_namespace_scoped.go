@@ -0,0 +1,26 @@
+// Fixture: a List scoped to the request's namespace only.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+}
+
+func InNamespace(ns string) interface{} { return ns }
+
+type SecretList struct{ Items []struct{} }
+
+type SecretReconciler struct{ Client Client }
+
+func (r *SecretReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var secrets SecretList
+	if err := r.Client.List(ctx, &secrets, InNamespace(req.NamespacedName.Namespace)); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,53 @@
+// Fixture: Lists bounded by a namespace that is not the request's, one
+// hardcoded and one configured, and a List paging through every object.
+package fixture
+
+import "context"
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+}
+
+func InNamespace(ns string) interface{} { return ns }
+func Continue(token string) interface{} { return token }
+
+type SecretList struct {
+	Items    []struct{}
+	Continue string
+}
+
+type ConfigMapList struct{ Items []struct{} }
+
+type PullSecretReconciler struct {
+	Client         Client
+	WatchNamespace string
+}
+
+func (r *PullSecretReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var system SecretList
+	if err := r.Client.List(ctx, &system, InNamespace("kube-system")); err != nil {
+		return Result{}, err
+	}
+	var configured ConfigMapList
+	if err := r.Client.List(ctx, &configured, InNamespace(r.WatchNamespace)); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+type InventoryReconciler struct{ Client Client }
+
+func (r *InventoryReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var page SecretList
+	for {
+		if err := r.Client.List(ctx, &page, Continue(page.Continue)); err != nil {
+			return Result{}, err
+		}
+		if page.Continue == "" {
+			return Result{}, nil
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Fixture: a List with no request-derived selector, written back in a loop.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type PodList struct{ Items []Pod }
+type Pod struct{ Name string }
+
+type SyncReconciler struct{ Client Client }
+
+func (r *SyncReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var pods PodList
+	if err := r.Client.List(ctx, &pods); err != nil {
+		return Result{}, err
+	}
+	for i := range pods.Items {
+		if err := r.Client.Update(ctx, &pods.Items[i]); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,41 @@
+// Fixture: a lister snapshot of the whole cache via labels.Everything(),
+// next to a lister List with a selector built from the request.
+package fixture
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Pod struct{ Name string }
+
+type PodLister interface {
+	List(selector labels.Selector) ([]*Pod, error)
+}
+
+type SnapshotReconciler struct{ podLister PodLister }
+
+func (r *SnapshotReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	pods, err := r.podLister.List(labels.Everything())
+	if err != nil {
+		return Result{}, err
+	}
+	_ = pods
+	return Result{}, nil
+}
+
+type SelectorReconciler struct{ podLister PodLister }
+
+func (r *SelectorReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	selector := labels.SelectorFromSet(labels.Set{"app": req.Name})
+	pods, err := r.podLister.List(selector)
+	if err != nil {
+		return Result{}, err
+	}
+	_ = pods
+	return Result{}, nil
+}
@@ -0,0 +1,65 @@
+// Fixture: the three ways of handling a NotFound Get: ignore it, run delete
+// logic, or recreate the missing object.
+package fixture
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Create(ctx context.Context, obj interface{}) error
+}
+
+type Widget struct{ Name string }
+
+type IgnoreReconciler struct{ Client Client }
+
+func (r *IgnoreReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var w Widget
+	if err := r.Client.Get(ctx, req.NamespacedName, &w); err != nil {
+		if apierrors.IsNotFound(err) {
+			return Result{}, nil
+		}
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+type CleanupReconciler struct{ Client Client }
+
+func (r *CleanupReconciler) cleanup(ctx context.Context, key NamespacedName) error { return nil }
+
+func (r *CleanupReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var w Widget
+	if err := r.Client.Get(ctx, req.NamespacedName, &w); err != nil {
+		if apierrors.IsNotFound(err) {
+			return Result{}, r.cleanup(ctx, req.NamespacedName)
+		}
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+
+type EnsureReconciler struct{ Client Client }
+
+func (r *EnsureReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var w Widget
+	if err := r.Client.Get(ctx, req.NamespacedName, &w); err != nil {
+		if apierrors.IsNotFound(err) {
+			w = Widget{Name: req.NamespacedName.Name}
+			if err := r.Client.Create(ctx, &w); err != nil {
+				return Result{}, err
+			}
+			return Result{}, nil
+		}
+		return Result{}, err
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,28 @@
+// Fixture: an early return while a paused annotation is set.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Delete(ctx context.Context, obj interface{}) error
+}
+
+type Machine struct{ Annotations map[string]string }
+
+type MachineReconciler struct{ Client Client }
+
+func (r *MachineReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var m Machine
+	if err := r.Client.Get(ctx, req.NamespacedName, &m); err != nil {
+		return Result{}, err
+	}
+	if _, ok := m.Annotations["cluster.x-k8s.io/paused"]; ok {
+		return Result{}, nil
+	}
+	return Result{}, r.Client.Delete(ctx, &m)
+}
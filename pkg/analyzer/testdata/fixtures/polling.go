@@ -0,0 +1,29 @@
+// Fixture: polling from Reconcile, a short readiness wait and an unbounded
+// loop, and requests enqueued into a workqueue by hand.
+package fixture
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Queue interface{ Add(item interface{}) }
+
+type JobReconciler struct {
+	queue Queue
+	ready func() (bool, error)
+}
+
+func (r *JobReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	if err := wait.PollImmediate(time.Second, 30*time.Second, r.ready); err != nil {
+		return Result{}, err
+	}
+	go wait.Until(func() { r.queue.Add(req) }, time.Minute, ctx.Done())
+	r.queue.Add(Request{Namespace: req.Namespace, Name: req.Name + "-cleanup"})
+	return Result{}, nil
+}
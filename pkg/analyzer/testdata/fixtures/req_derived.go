@@ -0,0 +1,47 @@
+// Fixture: request taint through multi-value assignments. The object from
+// lookup(req) scopes the first List; the error result does not, so the
+// namespace picked from it leaves the second List unscoped.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type Client interface {
+	List(ctx context.Context, list interface{}, opts ...interface{}) error
+}
+
+type ListOptions struct{ Namespace string }
+
+type Tenant struct{ Namespace string }
+type ConfigMapList struct{ Items []struct{} }
+
+type TenantReconciler struct{ Client Client }
+
+func (r *TenantReconciler) lookup(req Request) (Tenant, error) {
+	return Tenant{Namespace: req.NamespacedName.Namespace}, nil
+}
+
+// fallbackNamespace picks the shared namespace when the lookup failed.
+func fallbackNamespace(err error) string {
+	if err != nil {
+		return "shared"
+	}
+	return ""
+}
+
+func (r *TenantReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	tenant, err := r.lookup(req)
+	var owned ConfigMapList
+	if err := r.Client.List(ctx, &owned, &ListOptions{Namespace: tenant.Namespace}); err != nil {
+		return Result{}, err
+	}
+	ns := fallbackNamespace(err)
+	var shared ConfigMapList
+	if err := r.Client.List(ctx, &shared, &ListOptions{Namespace: ns}); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
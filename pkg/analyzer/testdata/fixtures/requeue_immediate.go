@@ -0,0 +1,33 @@
+// Fixture: an immediate requeue after a status write.
+package fixture
+
+import "context"
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ Requeue bool }
+
+type StatusWriter interface {
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Status() StatusWriter
+}
+
+type Job struct{ Status struct{ Ready bool } }
+
+type JobReconciler struct{ Client Client }
+
+func (r *JobReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var job Job
+	if err := r.Client.Get(ctx, req.NamespacedName, &job); err != nil {
+		return Result{}, err
+	}
+	job.Status.Ready = true
+	if err := r.Client.Status().Update(ctx, &job); err != nil {
+		return Result{}, err
+	}
+	return Result{Requeue: true}, nil
+}
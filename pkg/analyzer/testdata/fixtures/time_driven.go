@@ -0,0 +1,34 @@
+// Fixture: renewal near expiry, decided against the wall clock.
+package fixture
+
+import (
+	"context"
+	"time"
+)
+
+type NamespacedName struct{ Namespace, Name string }
+type Request struct{ NamespacedName NamespacedName }
+type Result struct{ RequeueAfter time.Duration }
+
+type Client interface {
+	Get(ctx context.Context, key NamespacedName, obj interface{}) error
+	Update(ctx context.Context, obj interface{}) error
+}
+
+type Certificate struct{ NotAfter time.Time }
+
+type CertReconciler struct{ Client Client }
+
+func (r *CertReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var cert Certificate
+	if err := r.Client.Get(ctx, req.NamespacedName, &cert); err != nil {
+		return Result{}, err
+	}
+	if time.Until(cert.NotAfter) < 24*time.Hour {
+		cert.NotAfter = time.Now().Add(90 * 24 * time.Hour)
+		if err := r.Client.Update(ctx, &cert); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{RequeueAfter: time.Hour}, nil
+}
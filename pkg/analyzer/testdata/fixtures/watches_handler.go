@@ -0,0 +1,39 @@
+// Fixture: extra watches through the builder, enqueuing the object itself
+// and its owner.
+package fixture
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+type Request struct{ Namespace, Name string }
+type Result struct{ Requeue bool }
+
+type Certificate struct{ Name string }
+type Secret struct{ Name string }
+type Order struct{ Name string }
+
+type Manager interface{}
+
+type Builder struct{}
+
+func NewControllerManagedBy(mgr Manager) *Builder                                       { return &Builder{} }
+func (b *Builder) For(obj interface{}, opts ...interface{}) *Builder                    { return b }
+func (b *Builder) Watches(obj interface{}, h interface{}, opts ...interface{}) *Builder { return b }
+func (b *Builder) Complete(r interface{}) error                                         { return nil }
+
+type CertificateReconciler struct{}
+
+func (r *CertificateReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	return Result{}, nil
+}
+
+func (r *CertificateReconciler) SetupWithManager(mgr Manager) error {
+	return NewControllerManagedBy(mgr).
+		For(&Certificate{}).
+		Watches(&Secret{}, &handler.EnqueueRequestForObject{}).
+		Watches(&Order{}, handler.EnqueueRequestForOwner(nil, nil, &Certificate{})).
+		Complete(r)
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// unresolvedController calls methods of a type from a package that cannot
+// be loaded.
+const unresolvedController = `package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Request struct{ Name string }
+type Result struct{}
+
+type Widget struct{}
+
+type WidgetReconciler struct{ Client client.Client }
+
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req Request) (Result, error) {
+	var widget Widget
+	if err := r.Client.Get(ctx, req.NamespacedName, &widget); err != nil {
+		return Result{}, err
+	}
+	if _, err := r.Client.RESTMapper().RESTMapping(widget.GroupKind()); err != nil {
+		return Result{}, err
+	}
+	return Result{}, nil
+}
+`
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{name: "typed", src: listController},
+		{name: "unresolved import", src: unresolvedController, want: []string{"line 21: unresolved method RESTMapping matched by name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyzer(t.TempDir(), false)
+			reconcilers, err := a.AnalyzeSource(map[string]string{"controllers/c.go": tt.src})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reconcilers) != 1 {
+				t.Fatalf("found %d reconcilers, want 1", len(reconcilers))
+			}
+			if got := reconcilers[0].Warnings; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Warnings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarningsUntyped(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "c.go", unresolvedController, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := &packages.Package{ID: "controllers", Name: "controllers", Fset: fset, Syntax: []*ast.File{file}}
+	var fn *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if d, ok := decl.(*ast.FuncDecl); ok && d.Name.Name == "Reconcile" {
+			fn = d
+		}
+	}
+
+	detector := NewPatternDetector(fset, pkg, []byte(unresolvedController), "req")
+	detector.DetectPatterns(fn)
+	// Without type information nothing is resolved, so only the
+	// package-wide warning is recorded.
+	want := []string{"no type information; all signals use name-based heuristics"}
+	if got := detector.Warnings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings() = %q, want %q", got, want)
+	}
+	detector.warnf("no type information; all signals use name-based heuristics")
+	if got := detector.Warnings(); len(got) != 1 {
+		t.Errorf("warnf recorded a duplicate: %q", got)
+	}
+}
@@ -0,0 +1,97 @@
+package models
+
+// Signal categories.
+const (
+	CategoryRead        = "read"
+	CategoryWrite       = "write"
+	CategoryControlFlow = "control_flow"
+	CategorySetup       = "setup"
+)
+
+// SignalInfo describes a signal type and its default score.
+type SignalInfo struct {
+	Type        string `json:"type"`
+	Score       int    `json:"score"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// SignalCatalog lists every signal type the detectors can emit. It is the
+// single source of default scores; detectors look their scores up here.
+var SignalCatalog = []SignalInfo{
+	{SignalListUnscoped, 3, CategoryRead, "client.List with no selector from req"},
+	{SignalListNamespaceScoped, 1, CategoryRead, "client.List with req.Namespace"},
+	{SignalListLabelScoped, 0, CategoryRead, "client.List with labels from req"},
+	{SignalListOwnerScoped, -1, CategoryRead, "client.List with owner ref from req"},
+	{SignalListFieldIndexed, -2, CategoryRead, "client.List by a field index registered in setup, value from req"},
+	{SignalListPaginated, 1, CategoryRead, "client.List with a Continue token (full enumeration)"},
+	{SignalCrossNamespaceList, 1, CategoryRead, "client.List in a hardcoded namespace other than the request's"},
+	{SignalListConfigNamespace, 2, CategoryRead, "client.List in a namespace from a receiver field or the environment"},
+	{SignalGetReqScoped, -1, CategoryRead, "client.Get(req.NamespacedName)"},
+	{SignalGetDerived, -1, CategoryRead, "client.Get with key derived from req"},
+	{SignalGetUnrelated, 1, CategoryRead, "client.Get with hardcoded/config key"},
+	{SignalGetSingleton, 0, CategoryRead, "client.Get of a singleton named by constant strings (config read)"},
+	{SignalDynamicGVK, 2, CategoryRead, "RESTMapper.RESTMapping or Scheme.ObjectKinds lookup (generic meta-controller)"},
+	{SignalBroadReadSurface, 1, CategoryRead, "Get/List of four or more distinct kinds (broad read surface)"},
+	{SignalGetMutateUpdate, -2, CategoryWrite, "Get(req), mutate the fetched object, Update it"},
+
+	{SignalLoopWrite, 3, CategoryWrite, "for loop containing Create/Update/Delete"},
+	{SignalLoopWriteScoped, 0, CategoryWrite, "loop writing items of a request-scoped list"},
+	{SignalBulkStatusUpdate, 3, CategoryWrite, "status subresource update of each item in a range over a List"},
+	{SignalDiffSync, 3, CategoryWrite, "compute desired, diff with actual, sync"},
+	{SignalDriftCheck, 1, CategoryWrite, "DeepEqual of desired and actual objects"},
+	{SignalSingleWrite, -1, CategoryWrite, "single Create/Update/Delete"},
+	{SignalDeleteAllOf, 3, CategoryWrite, "client.DeleteAllOf with no selector from req"},
+	{SignalDeleteAllOfScoped, 1, CategoryWrite, "client.DeleteAllOf scoped by namespace/labels from req"},
+	{SignalCreateOrUpdate, -1, CategoryWrite, "controllerutil.CreateOrUpdate"},
+	{SignalStatusUpdate, 0, CategoryWrite, "status subresource update"},
+	{SignalStatusCondition, 0, CategoryWrite, "status condition set via meta.SetStatusCondition"},
+	{SignalOwnerReference, -1, CategoryWrite, "owner reference set on a child via controllerutil"},
+	{SignalServerSideApply, -1, CategoryWrite, "client.Patch with client.Apply (server-side apply)"},
+
+	{SignalNotFoundEarlyReturn, -2, CategoryControlFlow, "if IsNotFound { handle delete }"},
+	{SignalNotFoundIgnore, -1, CategoryControlFlow, "if IsNotFound { return nil }"},
+	{SignalCreateOnMissing, 1, CategoryControlFlow, "if IsNotFound { Create(...) }"},
+	{SignalFinalizerHandling, -1, CategoryControlFlow, "finalizer add/remove pattern"},
+	{SignalPauseCheck, -1, CategoryControlFlow, "early return on a paused annotation"},
+	{SignalBuildDesiredState, 2, CategoryControlFlow, "build full desired state then apply"},
+	{SignalApplyRendered, 3, CategoryControlFlow, "loop applying a rendered []client.Object or []runtime.Object set"},
+	{SignalListIndexed, 1, CategoryControlFlow, "List items indexed into a map by name"},
+	{SignalTimeDriven, 1, CategoryControlFlow, "write or requeue gated on time.Now/Since/Until"},
+	{SignalHashGate, -1, CategoryControlFlow, "hash of the input compared with one stored in an annotation"},
+	{SignalPollWait, 1, CategoryControlFlow, "bounded wait.Poll* readiness wait"},
+	{SignalRequeueImmediate, 1, CategoryControlFlow, "return Result{Requeue: true} (immediate requeue)"},
+	{SignalPollLoop, 3, CategoryControlFlow, "unbounded/long wait.Until or wait.Poll* loop"},
+	{SignalManualEnqueue, 2, CategoryControlFlow, "workqueue Add*/channel send of requests from Reconcile"},
+	{SignalConflictRetry, 1, CategoryControlFlow, "retry.RetryOnConflict/OnError around a write"},
+
+	{SignalOwnsResources, -1, CategorySetup, ".Owns() in setup"},
+	{SignalWatchesWithHandler, -1, CategorySetup, ".Watches() with EnqueueRequestForOwner"},
+	{SignalWatchesMapFunc, 1, CategorySetup, ".Watches() with EnqueueRequestsFromMapFunc fan-out"},
+	{SignalEventPredicate, -1, CategorySetup, "built-in change predicate (generation, label, annotation or resource version)"},
+	{SignalDynamicWatch, -1, CategorySetup, "tracker.Watch() on a referenced object from Reconcile"},
+}
+
+var signalIndex = func() map[string]SignalInfo {
+	index := make(map[string]SignalInfo, len(SignalCatalog))
+	for _, info := range SignalCatalog {
+		index[info.Type] = info
+	}
+	return index
+}()
+
+// LookupSignal returns the catalog entry for a signal type.
+func LookupSignal(sigType string) (SignalInfo, bool) {
+	info, ok := signalIndex[sigType]
+	return info, ok
+}
+
+// DefaultScore returns the catalog score for a signal type. Detectors must
+// only emit cataloged types, so an unknown type is a programming error.
+func DefaultScore(sigType string) int {
+	info, ok := signalIndex[sigType]
+	if !ok {
+		panic("signal type " + sigType + " missing from SignalCatalog")
+	}
+	return info.Score
+}
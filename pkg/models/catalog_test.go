@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestSignalCatalog(t *testing.T) {
+	categories := map[string]bool{CategoryRead: true, CategoryWrite: true, CategoryControlFlow: true, CategorySetup: true}
+	seen := make(map[string]bool)
+	for _, info := range SignalCatalog {
+		if seen[info.Type] {
+			t.Errorf("signal %s cataloged twice", info.Type)
+		}
+		seen[info.Type] = true
+		if !categories[info.Category] {
+			t.Errorf("signal %s has unknown category %q", info.Type, info.Category)
+		}
+		if info.Description == "" {
+			t.Errorf("signal %s has no description", info.Type)
+		}
+		if got, ok := LookupSignal(info.Type); !ok || got != info {
+			t.Errorf("LookupSignal(%s) = %+v, %t, want %+v", info.Type, got, ok, info)
+		}
+		if got := DefaultScore(info.Type); got != info.Score {
+			t.Errorf("DefaultScore(%s) = %d, want %d", info.Type, got, info.Score)
+		}
+	}
+}
+
+func TestUnknownSignal(t *testing.T) {
+	if _, ok := LookupSignal("no_such_signal"); ok {
+		t.Error("LookupSignal found an uncataloged signal")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("DefaultScore of an uncataloged signal did not panic")
+		}
+	}()
+	DefaultScore("no_such_signal")
+}
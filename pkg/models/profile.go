@@ -0,0 +1,91 @@
+package models
+
+import "fmt"
+
+// Thresholds are the upper score bounds of the classification buckets.
+// Scores above MostlySoTW classify as sotw.
+type Thresholds struct {
+	EdgeTriggered float64 `json:"edge_triggered" yaml:"edge_triggered"`
+	MostlyEdge    float64 `json:"mostly_edge" yaml:"mostly_edge"`
+	MostlySoTW    float64 `json:"mostly_sotw" yaml:"mostly_sotw"`
+}
+
+// ScoringProfile overrides signal scores and classification thresholds.
+// Signal types not listed in Scores keep the score assigned at detection.
+type ScoringProfile struct {
+	Name       string         `json:"name" yaml:"name"`
+	Scores     map[string]int `json:"scores" yaml:"scores"`
+	Thresholds Thresholds     `json:"thresholds" yaml:"thresholds"`
+}
+
+// DefaultProfile returns the built-in profile: the catalog scores and
+// the Threshold* constants.
+func DefaultProfile() ScoringProfile {
+	return ScoringProfile{
+		Name: "default",
+		Thresholds: Thresholds{
+			EdgeTriggered: ThresholdEdgeTriggered,
+			MostlyEdge:    ThresholdMostlyEdge,
+			MostlySoTW:    ThresholdMostlySoTW,
+		},
+	}
+}
+
+// Score returns the profile's score for a signal.
+func (p ScoringProfile) Score(sig Signal) int {
+	if score, ok := p.Scores[sig.Type]; ok {
+		return score
+	}
+	return sig.Score
+}
+
+// Classify computes score and classification from signals under the profile.
+func (p ScoringProfile) Classify(signals []Signal) (int, string) {
+	trace := p.Explain(signals)
+	return trace.Score, trace.Classification
+}
+
+// Explain scores signals in order under the profile, recording the running
+// total and the threshold bucket the final score falls into.
+func (p ScoringProfile) Explain(signals []Signal) DecisionTrace {
+	trace := DecisionTrace{Steps: []TraceStep{}}
+	for _, sig := range signals {
+		score := p.Score(sig)
+		trace.Score += score
+		trace.Steps = append(trace.Steps, TraceStep{
+			Type:  sig.Type,
+			Line:  sig.Line,
+			Score: score,
+			Total: trace.Score,
+		})
+	}
+
+	trace.Classification = p.ClassifyScore(float64(trace.Score))
+	t := p.Thresholds
+	switch trace.Classification {
+	case "edge_triggered":
+		trace.Rule = fmt.Sprintf("score <= %g", t.EdgeTriggered)
+	case "mostly_edge":
+		trace.Rule = fmt.Sprintf("%g < score <= %g", t.EdgeTriggered, t.MostlyEdge)
+	case "mostly_sotw":
+		trace.Rule = fmt.Sprintf("%g < score <= %g", t.MostlyEdge, t.MostlySoTW)
+	default:
+		trace.Rule = fmt.Sprintf("score > %g", t.MostlySoTW)
+	}
+
+	return trace
+}
+
+// ClassifyScore maps a (possibly averaged) score to a classification.
+func (p ScoringProfile) ClassifyScore(score float64) string {
+	switch {
+	case score <= p.Thresholds.EdgeTriggered:
+		return "edge_triggered"
+	case score <= p.Thresholds.MostlyEdge:
+		return "mostly_edge"
+	case score <= p.Thresholds.MostlySoTW:
+		return "mostly_sotw"
+	default:
+		return "sotw"
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import "testing"
+
+func TestClassifyScore(t *testing.T) {
+	p := DefaultProfile()
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{ThresholdEdgeTriggered - 1, "edge_triggered"},
+		{ThresholdEdgeTriggered, "edge_triggered"},
+		{ThresholdEdgeTriggered + 0.5, "mostly_edge"},
+		{ThresholdMostlyEdge, "mostly_edge"},
+		{ThresholdMostlySoTW, "mostly_sotw"},
+		{ThresholdMostlySoTW + 0.5, "sotw"},
+	}
+	for _, tt := range tests {
+		if got := p.ClassifyScore(tt.score); got != tt.want {
+			t.Errorf("ClassifyScore(%g) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	signals := []Signal{
+		{Type: SignalListUnscoped, Line: 10, Score: 3},
+		{Type: SignalGetReqScoped, Line: 12, Score: -1},
+	}
+	tests := []struct {
+		name    string
+		profile ScoringProfile
+		score   int
+		class   string
+		rule    string
+	}{
+		{
+			name:    "default",
+			profile: DefaultProfile(),
+			score:   2,
+			class:   "mostly_sotw",
+			rule:    "0 < score <= 3",
+		},
+		{
+			name:    "override",
+			profile: ScoringProfile{Scores: map[string]int{SignalListUnscoped: 0}, Thresholds: DefaultProfile().Thresholds},
+			score:   -1,
+			class:   "mostly_edge",
+			rule:    "-3 < score <= 0",
+		},
+		{
+			name:    "edge triggered",
+			profile: ScoringProfile{Scores: map[string]int{SignalListUnscoped: -5}, Thresholds: DefaultProfile().Thresholds},
+			score:   -6,
+			class:   "edge_triggered",
+			rule:    "score <= -3",
+		},
+		{
+			name:    "sotw",
+			profile: ScoringProfile{Scores: map[string]int{SignalListUnscoped: 8}, Thresholds: DefaultProfile().Thresholds},
+			score:   7,
+			class:   "sotw",
+			rule:    "score > 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := tt.profile.Explain(signals)
+			if trace.Score != tt.score || trace.Classification != tt.class || trace.Rule != tt.rule {
+				t.Errorf("Explain() = %d %s (%s), want %d %s (%s)", trace.Score, trace.Classification, trace.Rule, tt.score, tt.class, tt.rule)
+			}
+			if len(trace.Steps) != len(signals) || trace.Steps[len(trace.Steps)-1].Total != tt.score {
+				t.Errorf("Explain() steps = %+v, want a running total ending at %d", trace.Steps, tt.score)
+			}
+		})
+	}
+}
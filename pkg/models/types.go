@@ -1,15 +1,73 @@
 package models
 
+import "time"
+
 // Repository represents a GitHub repository to analyze.
 type Repository struct {
-	URL       string `json:"url"`
-	Name      string `json:"name"`
-	Owner     string `json:"owner"`
-	Stars     int    `json:"stars"`
-	Source    string `json:"source"` // "cncf", "github-search", "curated"
-	LocalPath string `json:"-"`      // Local clone path
+	URL       string `json:"url" yaml:"url"`
+	Name      string `json:"name" yaml:"name"`
+	Owner     string `json:"owner" yaml:"owner"`
+	Stars     int    `json:"stars" yaml:"stars"`
+	Source    string `json:"source" yaml:"source"` // "cncf", "github-search", "curated"
+	LocalPath string `json:"-" yaml:"-"`           // Local clone path
+	Archive   string `json:"-" yaml:"-"`           // Source archive to extract instead of cloning
+}
+
+// RepoInventory summarizes what was discovered in a repository without
+// running pattern detection.
+type RepoInventory struct {
+	Repo              string `json:"repo"`
+	Packages          int    `json:"packages"`
+	PackageErrors     int    `json:"package_errors"`               // packages that failed to load
+	Reconcilers       int    `json:"reconcilers"`
+	AnalysisErrors    int    `json:"analysis_errors,omitempty"`    // Reconcile functions whose analysis failed
+	LoadQuality       string `json:"load_quality"`                 // LoadQuality* value
+	ModulePath        string `json:"module_path,omitempty"`        // from the root go.mod
+	ControllerRuntime string `json:"controller_runtime,omitempty"` // required controller-runtime version
+}
+
+// NeedsReview reports whether the repository's results may be incomplete:
+// some packages failed to load or lost type information, the analysis of a
+// Reconcile function failed, or no Reconcile function was found.
+func (inv RepoInventory) NeedsReview() bool {
+	return inv.PackageErrors > 0 || inv.LoadQuality != LoadQualityFull || inv.AnalysisErrors > 0 || inv.Reconcilers == 0
+}
+
+// LoadQuality values describe how much type information a repository's
+// analysis had.
+const (
+	LoadQualityFull       = "full"        // every analyzed package type-checked
+	LoadQualityPartial    = "partial"     // some packages type-checked with errors
+	LoadQualitySyntaxOnly = "syntax_only" // some packages analyzed from syntax alone
+	LoadQualityFailed     = "failed"      // no package could be loaded
+)
+
+// RepoTiming records how long each phase of a repository's analysis took.
+type RepoTiming struct {
+	Repo    string        `json:"repo"`
+	Clone   time.Duration `json:"clone_ns"`
+	Analyze time.Duration `json:"analyze_ns"`
+}
+
+// Total returns the combined clone and analyze duration.
+func (t RepoTiming) Total() time.Duration {
+	return t.Clone + t.Analyze
 }
 
+// RepoError records a failure to process a repository.
+type RepoError struct {
+	Repo  string `json:"repo"`
+	Phase string `json:"phase"` // Phase* value
+	Error string `json:"error"`
+}
+
+// Phases at which a repository can fail.
+const (
+	PhaseClone   = "clone"   // cloning or extracting the repository
+	PhaseAnalyze = "analyze" // loading packages and detecting patterns
+	PhaseWrite   = "write"   // writing results
+)
+
 // Reconciler represents a single Reconcile function.
 type Reconciler struct {
 	ID             string   `json:"id"`              // unique: repo#file#line
@@ -23,54 +81,166 @@ type Reconciler struct {
 	// Scoring.
 	Score          int      `json:"score"`
 	Classification string   `json:"classification"` // edge_triggered, mostly_edge, mostly_sotw, sotw
+	Label          string   `json:"label,omitempty"` // human-assigned classification, for calibration
 
 	// Detected signals.
 	Signals        []Signal `json:"signals"`
 
 	// Metadata.
+	PrimaryType    string   `json:"primary_type,omitempty"`   // type passed to .For() in setup
 	WatchedTypes   []string `json:"watched_types,omitempty"`  // if discoverable
+	ControllerOptions *ControllerOptions `json:"controller_options,omitempty"` // from WithOptions()/controller.New() in setup
+	Predicates     []string `json:"predicates,omitempty"`     // built-in change predicates in setup, e.g. "LabelChangedPredicate"
 	HasFinalizer   bool     `json:"has_finalizer"`
+	IsGeneric      bool     `json:"is_generic"`               // reads objects via meta.Accessor/unstructured, or resolves kinds at runtime
+	RequeuesAlways bool     `json:"requeues_always"`          // every return requests a requeue
+	NeverRequeues  bool     `json:"never_requeues"`           // no return requests a requeue
+	Direction      string   `json:"direction,omitempty"`      // "status" (reads spec, writes status) or "orchestrator"
+	IsWebhookAttached bool  `json:"is_webhook_attached"`      // receiver also implements Default/Validate* webhook methods
+	IsFunctional   bool     `json:"is_functional"`            // function literal converted with reconcile.Func
+	ReadOnly       bool     `json:"read_only"`                // no client Create/Update/Delete/Patch
+	HasConditions  bool     `json:"has_conditions"`           // sets status conditions via meta.SetStatusCondition
+	ManagesChildren bool    `json:"manages_children"`         // sets owner references on objects it writes
+	RespectsPause  bool     `json:"respects_pause"`           // returns early while a paused annotation is set
+	DynamicWatches bool     `json:"dynamic_watches"`          // starts watches from Reconcile via an object tracker
+	ReadKinds      int      `json:"read_kinds"`               // distinct kinds read through Get/List or listers
+	HasHashGate    bool     `json:"has_hash_gate"`            // compares an input hash with one stored in an annotation
+	ExternalEffects []string `json:"external_effects,omitempty"` // calls acting outside the Kubernetes API, e.g. "exec.Command"
+	LoadQuality    string   `json:"load_quality,omitempty"`   // repo LoadQuality* value: full, partial, syntax_only or failed
+	ToolVersion    string   `json:"tool_version,omitempty"`   // survey build that produced the record
+	Warnings       []string `json:"warnings,omitempty"`       // where type resolution fell back to name heuristics
+	Trace          *DecisionTrace `json:"trace,omitempty"`    // how the classification was reached, with --explain
 	FullSource     string   `json:"full_source,omitempty"`    // optional: full function source
 }
 
+// ControllerOptions records the controller options a setup function sets,
+// which put the classification in context: high concurrency or an aggressive
+// rate limiter suggests heavy reprocessing.
+type ControllerOptions struct {
+	MaxConcurrentReconciles string `json:"max_concurrent_reconciles,omitempty"` // constant value, or the expression, e.g. "r.Workers"
+	RateLimiter             string `json:"rate_limiter,omitempty"`              // constructor, e.g. "workqueue.NewItemExponentialFailureRateLimiter"
+}
+
+// ReconcilerRef locates a discovered Reconcile function, without analysis.
+type ReconcilerRef struct {
+	ID           string `json:"id"`            // unique: repo#file#line
+	Repo         string `json:"repo"`
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	ReceiverType string `json:"receiver_type"`
+	ReceiverPkg  string `json:"receiver_pkg"`
+}
+
+// DecisionTrace records how a reconciler's classification was reached.
+type DecisionTrace struct {
+	Steps          []TraceStep `json:"steps"`
+	Score          int         `json:"score"`
+	Rule           string      `json:"rule"`           // threshold bucket matched, e.g. "score <= -3"
+	Classification string      `json:"classification"`
+}
+
+// TraceStep is one scored signal and the running total after it.
+type TraceStep struct {
+	Type  string `json:"type"`
+	Line  int    `json:"line"`
+	Score int    `json:"score"`
+	Total int    `json:"total"`
+}
+
 // Signal represents a detected pattern.
 type Signal struct {
 	Type        string `json:"type"`         // e.g., "list_unscoped", "get_req_scoped"
 	Line        int    `json:"line"`
+	File        string `json:"file,omitempty"`         // file of Line, if not the reconciler's (e.g. setup signals)
 	Score       int    `json:"score"`
 	Snippet     string `json:"snippet"`      // relevant code snippet
 	Description string `json:"description"`  // human-readable explanation
+	FieldManager string `json:"field_manager,omitempty"` // Patch field owner, if set
+	ObjectKind  string `json:"object_kind,omitempty"`  // listed kind, e.g. "Pod", for List signals
+	Permalink   string `json:"permalink,omitempty"`    // blob URL of Line, with --link-base
+}
+
+// SignalRecord is a signal flattened with the reconciler it belongs to, for
+// signal-per-line output. A reconciler without signals is written as one
+// record with an empty signal type.
+type SignalRecord struct {
+	ReconcilerID    string `json:"reconciler_id"`
+	Repo            string `json:"repo"`
+	File            string `json:"file"`                  // the reconciler's file
+	ReconcilerScore int    `json:"reconciler_score"`
+	Classification  string `json:"classification"`
+	SignalFile      string `json:"signal_file,omitempty"` // Signal.File, which File shadows
+	Signal
 }
 
 // SignalType constants.
 const (
 	// Read patterns.
-	SignalListUnscoped       = "list_unscoped"        // client.List with no selector from req (+3)
-	SignalListNamespaceScoped = "list_namespace_scoped" // client.List with req.Namespace (+1)
-	SignalListLabelScoped    = "list_label_scoped"    // client.List with labels from req (0)
-	SignalListOwnerScoped    = "list_owner_scoped"    // client.List with owner ref from req (-1)
-	SignalGetReqScoped       = "get_req_scoped"       // client.Get(req.NamespacedName) (-1)
-	SignalGetDerived         = "get_derived"          // client.Get with key derived from req (-1)
-	SignalGetUnrelated       = "get_unrelated"        // client.Get with hardcoded/config key (+1)
+	SignalListUnscoped       = "list_unscoped"        // client.List with no selector from req
+	SignalListNamespaceScoped = "list_namespace_scoped" // client.List with req.Namespace
+	SignalListLabelScoped    = "list_label_scoped"    // client.List with labels from req
+	SignalListOwnerScoped    = "list_owner_scoped"    // client.List with owner ref from req
+	SignalListFieldIndexed   = "list_field_indexed"   // client.List by a setup-registered field index with value from req
+	SignalListPaginated      = "list_paginated"       // client.List with a Continue token (full enumeration)
+	SignalCrossNamespaceList = "cross_namespace_list" // client.List in a hardcoded namespace
+	SignalListConfigNamespace = "list_config_namespace" // client.List in a namespace from config (r.Namespace, env)
+	SignalGetReqScoped       = "get_req_scoped"       // client.Get(req.NamespacedName)
+	SignalGetDerived         = "get_derived"          // client.Get with key derived from req
+	SignalGetUnrelated       = "get_unrelated"        // client.Get with hardcoded/config key
+	SignalGetSingleton       = "get_singleton"        // client.Get with a NamespacedName of string literals
+	SignalGetMutateUpdate    = "get_mutate_update"    // Get(req), mutate the object, Update it
+	SignalDynamicGVK         = "dynamic_gvk"          // RESTMapper.RESTMapping/Scheme.ObjectKinds in Reconcile
+	SignalBroadReadSurface   = "broad_read_surface"   // Get/List of many distinct kinds
 
 	// Write patterns.
-	SignalLoopWrite          = "loop_write"           // for loop containing Create/Update/Delete (+3)
-	SignalDiffSync           = "diff_sync"            // compute desired, diff with actual, sync (+3)
-	SignalSingleWrite        = "single_write"         // single Create/Update/Delete (-1)
-	SignalCreateOrUpdate     = "create_or_update"     // controllerutil.CreateOrUpdate (-1)
-	SignalStatusUpdate       = "status_update"        // status subresource update (0)
+	SignalLoopWrite          = "loop_write"           // for loop containing Create/Update/Delete
+	SignalLoopWriteScoped    = "loop_write_scoped"    // loop writing items of a request-scoped list
+	SignalBulkStatusUpdate   = "bulk_status_update"   // Status().Update/Patch of each item in a range over a List
+	SignalDiffSync           = "diff_sync"            // compute desired, diff with actual, sync
+	SignalDriftCheck         = "drift_check"          // DeepEqual of desired and actual objects
+	SignalSingleWrite        = "single_write"         // single Create/Update/Delete
+	SignalDeleteAllOf        = "delete_all_of"        // client.DeleteAllOf without request-scoped selectors
+	SignalDeleteAllOfScoped  = "delete_all_of_scoped" // client.DeleteAllOf scoped by namespace/labels from req
+	SignalCreateOrUpdate     = "create_or_update"     // controllerutil.CreateOrUpdate
+	SignalStatusUpdate       = "status_update"        // status subresource update
+	SignalServerSideApply    = "server_side_apply"    // client.Patch with client.Apply
+	SignalStatusCondition    = "status_condition"     // meta.SetStatusCondition on the object's conditions
+	SignalOwnerReference     = "owner_reference"      // controllerutil.SetControllerReference/SetOwnerReference
 
 	// Control flow patterns.
-	SignalNotFoundEarlyReturn = "notfound_early_return" // if IsNotFound { handle delete } (-2)
-	SignalNotFoundIgnore     = "notfound_ignore"      // if IsNotFound { return nil } (-1)
-	SignalFinalizerHandling  = "finalizer_handling"   // finalizer add/remove pattern (-1)
-	SignalBuildDesiredState  = "build_desired_state"  // build full desired state then apply (+2)
+	SignalNotFoundEarlyReturn = "notfound_early_return" // if IsNotFound { handle delete }
+	SignalNotFoundIgnore     = "notfound_ignore"      // if IsNotFound { return nil }
+	SignalCreateOnMissing    = "reconcile_create_on_missing" // if IsNotFound { Create(...) }
+	SignalFinalizerHandling  = "finalizer_handling"   // finalizer add/remove pattern
+	SignalPauseCheck         = "pause_check"          // early return on a paused annotation
+	SignalBuildDesiredState  = "build_desired_state"  // build full desired state then apply
+	SignalApplyRendered      = "apply_rendered"       // loop applying a rendered []client.Object/[]runtime.Object
+	SignalListIndexed        = "list_indexed"         // List items indexed into a map by name
+	SignalPollWait           = "poll_wait"            // bounded wait.Poll* readiness wait
+	SignalPollLoop           = "poll_loop"            // unbounded/long wait.Until or wait.Poll* loop
+	SignalRequeueImmediate   = "requeue_immediate"    // return Result{Requeue: true}
+	SignalTimeDriven         = "time_driven"          // write/requeue gated on time.Now/Since/Until
+	SignalHashGate           = "hash_gate"            // input hash compared with a hash stored in an annotation
+	SignalManualEnqueue      = "manual_enqueue"       // workqueue Add*/channel send of requests from Reconcile
+	SignalConflictRetry      = "conflict_retry"       // retry.RetryOnConflict/OnError around a write
 
 	// Setup patterns (from SetupWithManager).
-	SignalOwnsResources      = "owns_resources"       // .Owns() in setup (-1)
-	SignalWatchesWithHandler = "watches_with_handler" // .Watches() with EnqueueRequestForOwner (-1)
+	SignalOwnsResources      = "owns_resources"       // .Owns() in setup
+	SignalWatchesWithHandler = "watches_with_handler" // .Watches() with EnqueueRequestForOwner
+	SignalWatchesMapFunc     = "watches_map_func"     // .Watches() with EnqueueRequestsFromMapFunc fan-out
+	SignalEventPredicate     = "event_predicate"      // built-in change predicate, e.g. predicate.LabelChangedPredicate
+	SignalDynamicWatch       = "dynamic_watch"        // tracker.Watch() on a referenced object from Reconcile
 )
 
+// Direction values.
+const (
+	DirectionStatus       = "status"       // reads Spec, writes only Status
+	DirectionOrchestrator = "orchestrator" // writes Spec or whole objects
+)
+
+// Classifications, ordered from most edge-triggered to most SoTW.
+var Classifications = []string{"edge_triggered", "mostly_edge", "mostly_sotw", "sotw"}
+
 // Classification thresholds.
 const (
 	ThresholdEdgeTriggered     = -3
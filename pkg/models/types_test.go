@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestNeedsReview(t *testing.T) {
+	complete := RepoInventory{Repo: "r", Packages: 3, Reconcilers: 2, LoadQuality: LoadQualityFull}
+	tests := []struct {
+		name   string
+		modify func(*RepoInventory)
+		want   bool
+	}{
+		{name: "complete", modify: func(*RepoInventory) {}, want: false},
+		{name: "package errors", modify: func(inv *RepoInventory) { inv.PackageErrors = 1 }, want: true},
+		{name: "partial types", modify: func(inv *RepoInventory) { inv.LoadQuality = LoadQualityPartial }, want: true},
+		{name: "analysis errors", modify: func(inv *RepoInventory) { inv.AnalysisErrors = 1 }, want: true},
+		{name: "no reconcilers", modify: func(inv *RepoInventory) { inv.Reconcilers = 0 }, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := complete
+			tt.modify(&inv)
+			if got := inv.NeedsReview(); got != tt.want {
+				t.Errorf("NeedsReview() = %t, want %t for %+v", got, tt.want, inv)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package output
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// SummaryAccumulator builds a Summary incrementally, so results can be
+// summarized as they stream in without retaining every reconciler.
+// It is safe for concurrent use.
+type SummaryAccumulator struct {
+	mu sync.Mutex
+
+	topN       int
+	summary    Summary
+	totalScore int
+	repos      map[string]*RepoRollup
+	repoScores map[string]int
+
+	// topSoTW keeps the highest scores (root is the lowest of them),
+	// topEdge the lowest scores (root is the highest of them).
+	topSoTW *reconcilerHeap
+	topEdge *reconcilerHeap
+}
+
+// NewSummaryAccumulator creates an accumulator keeping the topN highest and
+// lowest scoring reconcilers. Among equal scores the reconciler added first
+// ranks first.
+func NewSummaryAccumulator(topN int) *SummaryAccumulator {
+	return &SummaryAccumulator{
+		topN: topN,
+		summary: Summary{
+			ByClassification: make(map[string]int),
+			ByRepo:           make(map[string]int),
+			SignalFrequency:  make(map[string]int),
+			Cooccurrence:     make(map[string]map[string]int),
+			ByPrimaryType:    make(map[string]map[string]int),
+			ByWatchedType:    make(map[string]map[string]int),
+		},
+		repos:      make(map[string]*RepoRollup),
+		repoScores: make(map[string]int),
+		topSoTW:    &reconcilerHeap{less: func(a, b models.Reconciler) bool { return a.Score < b.Score }},
+		topEdge:    &reconcilerHeap{less: func(a, b models.Reconciler) bool { return a.Score > b.Score }},
+	}
+}
+
+// Add accumulates a single reconciler.
+func (a *SummaryAccumulator) Add(r models.Reconciler) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.summary.TotalReconcilers++
+	a.summary.ByClassification[r.Classification]++
+	a.summary.ByRepo[r.Repo]++
+	a.totalScore += r.Score
+	if r.ReadOnly {
+		a.summary.ReadOnly++
+	}
+
+	for _, sig := range r.Signals {
+		a.summary.SignalFrequency[sig.Type]++
+	}
+	countPairs(a.summary.Cooccurrence, r.Signals)
+
+	if r.PrimaryType != "" {
+		countType(a.summary.ByPrimaryType, r.PrimaryType, r.Classification)
+	}
+	for _, t := range r.WatchedTypes {
+		countType(a.summary.ByWatchedType, t, r.Classification)
+	}
+
+	rollup, ok := a.repos[r.Repo]
+	if !ok {
+		rollup = &RepoRollup{Repo: r.Repo, ByClassification: make(map[string]int)}
+		a.repos[r.Repo] = rollup
+	}
+	rollup.Reconcilers++
+	rollup.ByClassification[r.Classification]++
+	a.repoScores[r.Repo] += r.Score
+
+	if a.topN > 0 {
+		a.topSoTW.offer(r, a.topN)
+		a.topEdge.offer(r, a.topN)
+	}
+}
+
+// Finalize returns the Summary of everything added so far.
+func (a *SummaryAccumulator) Finalize() Summary {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	summary := a.summary
+	summary.ByClassification = copyCounts(a.summary.ByClassification)
+	summary.ByRepo = copyCounts(a.summary.ByRepo)
+	summary.SignalFrequency = copyCounts(a.summary.SignalFrequency)
+	summary.Cooccurrence = copyTypeCounts(a.summary.Cooccurrence)
+	summary.ByPrimaryType = copyTypeCounts(a.summary.ByPrimaryType)
+	summary.ByWatchedType = copyTypeCounts(a.summary.ByWatchedType)
+
+	if summary.TotalReconcilers > 0 {
+		summary.AverageScore = float64(a.totalScore) / float64(summary.TotalReconcilers)
+	}
+
+	summary.Repos = make([]RepoRollup, 0, len(a.repos))
+	for repo, rollup := range a.repos {
+		r := *rollup
+		r.ByClassification = copyCounts(rollup.ByClassification)
+		r.AverageScore = float64(a.repoScores[repo]) / float64(r.Reconcilers)
+		r.Classification = repoVerdict(r)
+		summary.Repos = append(summary.Repos, r)
+	}
+	sort.Slice(summary.Repos, func(i, j int) bool {
+		return summary.Repos[i].Repo < summary.Repos[j].Repo
+	})
+
+	if a.topN > 0 {
+		summary.TopSoTW = a.topSoTW.sorted()
+		summary.TopEdge = a.topEdge.sorted()
+	}
+
+	return summary
+}
+
+// copyCounts returns a copy of a count map.
+func copyCounts(m map[string]int) map[string]int {
+	c := make(map[string]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// countType counts a reconciler of the given classification for type t.
+func countType(byType map[string]map[string]int, t, classification string) {
+	if byType[t] == nil {
+		byType[t] = make(map[string]int)
+	}
+	byType[t][classification]++
+}
+
+// countPairs counts a reconciler once for every pair of distinct signal
+// types among its signals, in both directions.
+func countPairs(cooccurrence map[string]map[string]int, signals []models.Signal) {
+	var sigTypes []string
+	seen := make(map[string]bool)
+	for _, sig := range signals {
+		if !seen[sig.Type] {
+			seen[sig.Type] = true
+			sigTypes = append(sigTypes, sig.Type)
+		}
+	}
+	for i, a := range sigTypes {
+		for _, b := range sigTypes[i+1:] {
+			countType(cooccurrence, a, b)
+			countType(cooccurrence, b, a)
+		}
+	}
+}
+
+// copyTypeCounts returns a deep copy of a type -> classification -> count map.
+func copyTypeCounts(m map[string]map[string]int) map[string]map[string]int {
+	c := make(map[string]map[string]int, len(m))
+	for k, v := range m {
+		c[k] = copyCounts(v)
+	}
+	return c
+}
+
+// reconcilerHeap is a bounded heap whose root is the "worst" retained
+// reconciler according to less, so it can be evicted by a better one. Ties
+// go to the reconciler offered first, as with a stable sort of all of them.
+type reconcilerHeap struct {
+	items []rankedReconciler
+	less  func(a, b models.Reconciler) bool
+	next  int
+}
+
+// rankedReconciler is a heap entry with its offer order.
+type rankedReconciler struct {
+	r   models.Reconciler
+	seq int
+}
+
+// worse reports whether a ranks below b: by less, then offered later.
+func (h *reconcilerHeap) worse(a, b rankedReconciler) bool {
+	if h.less(a.r, b.r) {
+		return true
+	}
+	return !h.less(b.r, a.r) && a.seq > b.seq
+}
+
+func (h *reconcilerHeap) Len() int           { return len(h.items) }
+func (h *reconcilerHeap) Less(i, j int) bool { return h.worse(h.items[i], h.items[j]) }
+func (h *reconcilerHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *reconcilerHeap) Push(x any)         { h.items = append(h.items, x.(rankedReconciler)) }
+
+func (h *reconcilerHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// offer adds r if the heap holds fewer than n items or r beats the root.
+func (h *reconcilerHeap) offer(r models.Reconciler, n int) {
+	item := rankedReconciler{r: r, seq: h.next}
+	h.next++
+	if h.Len() < n {
+		heap.Push(h, item)
+		return
+	}
+	if h.worse(h.items[0], item) {
+		h.items[0] = item
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the retained reconcilers, best first.
+func (h *reconcilerHeap) sorted() []models.Reconciler {
+	items := make([]rankedReconciler, len(h.items))
+	copy(items, h.items)
+	sort.Slice(items, func(i, j int) bool {
+		return h.worse(items[j], items[i])
+	})
+	out := make([]models.Reconciler, len(items))
+	for i, item := range items {
+		out[i] = item.r
+	}
+	return out
+}
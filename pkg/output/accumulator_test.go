@@ -0,0 +1,317 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// scoredReconcilers returns n reconcilers spread over three repos, scored
+// -n/2 .. n/2-1.
+func scoredReconcilers(n int) []models.Reconciler {
+	reconcilers := make([]models.Reconciler, n)
+	for i := range reconcilers {
+		score := i - n/2
+		reconcilers[i] = models.Reconciler{
+			ID:             fmt.Sprintf("r%d", i),
+			Repo:           fmt.Sprintf("repo%d", i%3),
+			Score:          score,
+			Classification: models.DefaultProfile().ClassifyScore(float64(score)),
+			Signals:        []models.Signal{{Type: models.SignalListUnscoped}},
+		}
+	}
+	return reconcilers
+}
+
+// tiedReconcilers returns n reconcilers over three repos whose scores repeat
+// every seven, with varying signals, types and write behavior.
+func tiedReconcilers(n int) []models.Reconciler {
+	sigTypes := []string{models.SignalListUnscoped, models.SignalGetReqScoped, models.SignalStatusUpdate}
+	reconcilers := make([]models.Reconciler, n)
+	for i := range reconcilers {
+		score := i%7 - 3
+		r := models.Reconciler{
+			ID:             fmt.Sprintf("r%d", i),
+			Repo:           fmt.Sprintf("repo%d", i%3),
+			Score:          score,
+			Classification: models.DefaultProfile().ClassifyScore(float64(score)),
+			ReadOnly:       i%4 == 0,
+			PrimaryType:    fmt.Sprintf("Kind%d", i%5),
+			WatchedTypes:   []string{"Pod", fmt.Sprintf("Kind%d", i%2)},
+		}
+		for j := 0; j <= i%3; j++ {
+			r.Signals = append(r.Signals, models.Signal{Type: sigTypes[(i+j)%3]})
+		}
+		reconcilers[i] = r
+	}
+	return reconcilers
+}
+
+// batchSummary is the reference the accumulator must match: every figure
+// computed over the whole slice, and the top lists by a stable sort of all
+// reconcilers sliced to topN.
+func batchSummary(reconcilers []models.Reconciler, topN int) Summary {
+	summary := Summary{
+		TotalReconcilers: len(reconcilers),
+		ByClassification: make(map[string]int),
+		ByRepo:           make(map[string]int),
+		SignalFrequency:  make(map[string]int),
+		Cooccurrence:     make(map[string]map[string]int),
+		ByPrimaryType:    make(map[string]map[string]int),
+		ByWatchedType:    make(map[string]map[string]int),
+		Repos:            []RepoRollup{},
+	}
+	count := func(m map[string]map[string]int, a, b string) {
+		if m[a] == nil {
+			m[a] = make(map[string]int)
+		}
+		m[a][b]++
+	}
+
+	total := 0
+	rollups := make(map[string]*RepoRollup)
+	repoTotals := make(map[string]int)
+	for _, r := range reconcilers {
+		summary.ByClassification[r.Classification]++
+		summary.ByRepo[r.Repo]++
+		total += r.Score
+		if r.ReadOnly {
+			summary.ReadOnly++
+		}
+		var distinct []string
+		for _, sig := range r.Signals {
+			summary.SignalFrequency[sig.Type]++
+			if !slices.Contains(distinct, sig.Type) {
+				distinct = append(distinct, sig.Type)
+			}
+		}
+		for _, a := range distinct {
+			for _, b := range distinct {
+				if a != b {
+					count(summary.Cooccurrence, a, b)
+				}
+			}
+		}
+		if r.PrimaryType != "" {
+			count(summary.ByPrimaryType, r.PrimaryType, r.Classification)
+		}
+		for _, typ := range r.WatchedTypes {
+			count(summary.ByWatchedType, typ, r.Classification)
+		}
+
+		if rollups[r.Repo] == nil {
+			rollups[r.Repo] = &RepoRollup{Repo: r.Repo, ByClassification: make(map[string]int)}
+		}
+		rollups[r.Repo].Reconcilers++
+		rollups[r.Repo].ByClassification[r.Classification]++
+		repoTotals[r.Repo] += r.Score
+	}
+	if len(reconcilers) > 0 {
+		summary.AverageScore = float64(total) / float64(len(reconcilers))
+	}
+	for repo, rollup := range rollups {
+		rollup.AverageScore = float64(repoTotals[repo]) / float64(rollup.Reconcilers)
+		rollup.Classification = repoVerdict(*rollup)
+		summary.Repos = append(summary.Repos, *rollup)
+	}
+	sort.Slice(summary.Repos, func(i, j int) bool { return summary.Repos[i].Repo < summary.Repos[j].Repo })
+
+	if topN > 0 {
+		top := func(better func(a, b models.Reconciler) bool) []models.Reconciler {
+			sorted := slices.Clone(reconcilers)
+			sort.SliceStable(sorted, func(i, j int) bool { return better(sorted[i], sorted[j]) })
+			return sorted[:min(topN, len(sorted))]
+		}
+		summary.TopSoTW = top(func(a, b models.Reconciler) bool { return a.Score > b.Score })
+		summary.TopEdge = top(func(a, b models.Reconciler) bool { return a.Score < b.Score })
+	}
+	return summary
+}
+
+func TestGenerateSummary(t *testing.T) {
+	tests := []struct {
+		name        string
+		reconcilers []models.Reconciler
+		topN        int
+	}{
+		{name: "tied scores", reconcilers: tiedReconcilers(50), topN: 5},
+		{name: "topN beyond the input", reconcilers: tiedReconcilers(4), topN: 10},
+		{name: "no top lists", reconcilers: tiedReconcilers(20)},
+		{name: "distinct scores", reconcilers: scoredReconcilers(20), topN: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateSummary(tt.reconcilers, tt.topN)
+			if want := batchSummary(tt.reconcilers, tt.topN); !reflect.DeepEqual(got, want) {
+				t.Errorf("GenerateSummary() =\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestSummaryAccumulatorConcurrent(t *testing.T) {
+	reconcilers := tiedReconcilers(200)
+	want := batchSummary(reconcilers, 5)
+
+	acc := NewSummaryAccumulator(5)
+	var wg sync.WaitGroup
+	for _, r := range reconcilers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			acc.Add(r)
+		}()
+	}
+	wg.Wait()
+	got := acc.Finalize()
+
+	// Which of several tied reconcilers makes a top list depends on the
+	// order of the concurrent Adds, so only the scores must agree there.
+	scores := func(reconcilers []models.Reconciler) []int {
+		var s []int
+		for _, r := range reconcilers {
+			s = append(s, r.Score)
+		}
+		return s
+	}
+	if !reflect.DeepEqual(scores(got.TopSoTW), scores(want.TopSoTW)) || !reflect.DeepEqual(scores(got.TopEdge), scores(want.TopEdge)) {
+		t.Errorf("top scores = %v / %v, want %v / %v", scores(got.TopSoTW), scores(got.TopEdge), scores(want.TopSoTW), scores(want.TopEdge))
+	}
+	got.TopSoTW, got.TopEdge = nil, nil
+	want.TopSoTW, want.TopEdge = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("concurrent summary =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestSummaryAccumulatorTop(t *testing.T) {
+	summary := GenerateSummary(scoredReconcilers(20), 3)
+	scores := func(reconcilers []models.Reconciler) []int {
+		var s []int
+		for _, r := range reconcilers {
+			s = append(s, r.Score)
+		}
+		return s
+	}
+	if got, want := scores(summary.TopSoTW), []int{9, 8, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TopSoTW scores = %v, want %v", got, want)
+	}
+	if got, want := scores(summary.TopEdge), []int{-10, -9, -8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TopEdge scores = %v, want %v", got, want)
+	}
+
+	// A snapshot is not changed by later additions.
+	acc := NewSummaryAccumulator(3)
+	acc.Add(models.Reconciler{Repo: "a", Classification: "sotw"})
+	snapshot := acc.Finalize()
+	acc.Add(models.Reconciler{Repo: "a", Classification: "sotw"})
+	if snapshot.ByClassification["sotw"] != 1 || snapshot.ByRepo["a"] != 1 {
+		t.Errorf("Finalize() snapshot changed by a later Add: %+v", snapshot)
+	}
+}
+
+func TestTypeAggregation(t *testing.T) {
+	acc := NewSummaryAccumulator(3)
+	for _, r := range []models.Reconciler{
+		{PrimaryType: "Widget", WatchedTypes: []string{"Widget", "Pod"}, Classification: "sotw"},
+		{PrimaryType: "Widget", WatchedTypes: []string{"Pod"}, Classification: "edge_triggered"},
+		{PrimaryType: "Gadget", WatchedTypes: []string{"Secret"}, Classification: "mostly_sotw"},
+		{Classification: "sotw"},
+	} {
+		acc.Add(r)
+	}
+	summary := acc.Finalize()
+
+	tests := []struct {
+		name   string
+		byType map[string]map[string]int
+		want   []TypeCount
+	}{
+		{
+			name:   "primary",
+			byType: summary.ByPrimaryType,
+			want:   []TypeCount{{"Widget", 2, 1}, {"Gadget", 1, 1}},
+		},
+		{
+			name:   "watched",
+			byType: summary.ByWatchedType,
+			want:   []TypeCount{{"Pod", 2, 1}, {"Secret", 1, 1}, {"Widget", 1, 1}},
+		},
+		{
+			name: "ties by SoTW count, then type",
+			byType: map[string]map[string]int{
+				"b": {"edge_triggered": 2},
+				"c": {"sotw": 1, "mostly_edge": 1},
+				"a": {"mostly_edge": 2},
+			},
+			want: []TypeCount{{"c", 2, 1}, {"a", 2, 0}, {"b", 2, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankTypes(tt.byType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankTypes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// The snapshot is a deep copy.
+	acc.Add(models.Reconciler{PrimaryType: "Widget", Classification: "sotw"})
+	if summary.ByPrimaryType["Widget"]["sotw"] != 1 {
+		t.Errorf("Finalize() snapshot changed by a later Add: %v", summary.ByPrimaryType)
+	}
+}
+
+func TestCooccurrence(t *testing.T) {
+	signals := func(types ...string) []models.Signal {
+		var s []models.Signal
+		for _, t := range types {
+			s = append(s, models.Signal{Type: t})
+		}
+		return s
+	}
+	acc := NewSummaryAccumulator(3)
+	for _, sigs := range [][]models.Signal{
+		signals("list", "loop_write", "list"),
+		signals("list", "loop_write", "status"),
+		signals("status"),
+		nil,
+	} {
+		acc.Add(models.Reconciler{Classification: "sotw", Signals: sigs})
+	}
+	summary := acc.Finalize()
+
+	wantMatrix := map[string]map[string]int{
+		"list":       {"loop_write": 2, "status": 1},
+		"loop_write": {"list": 2, "status": 1},
+		"status":     {"list": 1, "loop_write": 1},
+	}
+	if !reflect.DeepEqual(summary.Cooccurrence, wantMatrix) {
+		t.Errorf("Cooccurrence = %v, want %v", summary.Cooccurrence, wantMatrix)
+	}
+
+	tests := []struct {
+		name   string
+		matrix map[string]map[string]int
+		want   []SignalPair
+	}{
+		{
+			name:   "by count, then types",
+			matrix: summary.Cooccurrence,
+			want:   []SignalPair{{"list", "loop_write", 2}, {"list", "status", 1}, {"loop_write", "status", 1}},
+		},
+		{name: "empty", matrix: map[string]map[string]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankPairs(tt.matrix); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankPairs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
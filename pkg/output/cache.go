@@ -0,0 +1,82 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// CacheEntry is the analysis of one repository as stored in a ResultCache.
+type CacheEntry struct {
+	Reconcilers []models.Reconciler  `json:"reconcilers"`
+	Inventory   models.RepoInventory `json:"inventory"`
+}
+
+// ResultCache stores analysis results in a directory, one JSON file per key.
+// Entries are written atomically, so concurrent use is safe.
+type ResultCache struct {
+	Dir string
+}
+
+// CacheKey derives a content-addressed cache key from everything that
+// determines an analysis, e.g. tool version, repo URL, commit and options.
+func CacheKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// path returns the file holding the entry for key.
+func (c ResultCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Load returns the entry stored for key, or nil if there is none.
+func (c ResultCache) Load(key string) (*CacheEntry, error) {
+	data, err := os.ReadFile(c.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse cache entry %s: %w", c.path(key), err)
+	}
+	return &entry, nil
+}
+
+// Store saves entry under key, replacing any previous entry.
+func (c ResultCache) Store(key string, entry CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// Calibration compares a scoring profile's classifications against
+// human-assigned labels.
+type Calibration struct {
+	Profile   string                    `json:"profile"`
+	Labeled   int                       `json:"labeled"`
+	Correct   int                       `json:"correct"`
+	Accuracy  float64                   `json:"accuracy"`
+	Confusion map[string]map[string]int `json:"confusion"` // label -> predicted -> count
+}
+
+// Calibrate re-classifies labeled reconcilers under the profile and
+// tallies agreement with their labels. Unlabeled reconcilers are ignored.
+func Calibrate(reconcilers []models.Reconciler, profile models.ScoringProfile) Calibration {
+	cal := Calibration{
+		Profile:   profile.Name,
+		Confusion: make(map[string]map[string]int),
+	}
+
+	for _, r := range reconcilers {
+		if r.Label == "" {
+			continue
+		}
+		_, predicted := profile.Classify(r.Signals)
+
+		cal.Labeled++
+		if predicted == r.Label {
+			cal.Correct++
+		}
+		if cal.Confusion[r.Label] == nil {
+			cal.Confusion[r.Label] = make(map[string]int)
+		}
+		cal.Confusion[r.Label][predicted]++
+	}
+
+	if cal.Labeled > 0 {
+		cal.Accuracy = float64(cal.Correct) / float64(cal.Labeled)
+	}
+
+	return cal
+}
+
+// PrintCalibration prints accuracy and the confusion matrix to the given writer.
+func PrintCalibration(w io.Writer, cal Calibration) {
+	fmt.Fprintf(w, "=== Calibration: %s ===\n\n", cal.Profile)
+	fmt.Fprintf(w, "Labeled Reconcilers: %d\n", cal.Labeled)
+	fmt.Fprintf(w, "Accuracy: %.1f%% (%d/%d)\n\n", 100*cal.Accuracy, cal.Correct, cal.Labeled)
+
+	// Rows are labels, columns are predictions. Labels outside the known
+	// classifications are listed after them.
+	rows := append([]string(nil), models.Classifications...)
+	for label := range cal.Confusion {
+		if !isClassification(label) {
+			rows = append(rows, label)
+		}
+	}
+
+	fmt.Fprintf(w, "Confusion Matrix (rows: label, columns: predicted):\n")
+	fmt.Fprintf(w, "  %-16s", "")
+	for _, class := range models.Classifications {
+		fmt.Fprintf(w, "%16s", class)
+	}
+	fmt.Fprintf(w, "\n")
+	for _, label := range rows {
+		fmt.Fprintf(w, "  %-16s", label)
+		for _, class := range models.Classifications {
+			fmt.Fprintf(w, "%16d", cal.Confusion[label][class])
+		}
+		fmt.Fprintf(w, "\n")
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// isClassification checks if s is one of the known classifications.
+func isClassification(s string) bool {
+	for _, class := range models.Classifications {
+		if s == class {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestCalibrate(t *testing.T) {
+	unscoped := []models.Signal{{Type: models.SignalListUnscoped, Score: 3}, {Type: models.SignalLoopWrite, Score: 3}}
+	reqScoped := []models.Signal{{Type: models.SignalGetReqScoped, Score: -1}, {Type: models.SignalGetMutateUpdate, Score: -2}, {Type: models.SignalSingleWrite, Score: -1}}
+	reconcilers := []models.Reconciler{
+		{ID: "a", Label: "sotw", Signals: unscoped},
+		{ID: "b", Label: "edge_triggered", Signals: reqScoped},
+		{ID: "c", Label: "edge_triggered", Signals: unscoped},
+		{ID: "d", Signals: unscoped}, // unlabeled
+	}
+
+	lenient := models.DefaultProfile()
+	lenient.Name = "lenient"
+	lenient.Scores = map[string]int{models.SignalListUnscoped: -3, models.SignalLoopWrite: -3}
+
+	tests := []struct {
+		profile   models.ScoringProfile
+		correct   int
+		confusion map[string]map[string]int
+	}{
+		{
+			profile: models.DefaultProfile(),
+			correct: 2,
+			confusion: map[string]map[string]int{
+				"sotw":           {"sotw": 1},
+				"edge_triggered": {"edge_triggered": 1, "sotw": 1},
+			},
+		},
+		{
+			profile: lenient,
+			correct: 2,
+			confusion: map[string]map[string]int{
+				"sotw":           {"edge_triggered": 1},
+				"edge_triggered": {"edge_triggered": 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile.Name, func(t *testing.T) {
+			cal := Calibrate(reconcilers, tt.profile)
+			if cal.Profile != tt.profile.Name || cal.Labeled != 3 || cal.Correct != tt.correct {
+				t.Errorf("Calibrate() = %s %d/%d, want %s %d/3", cal.Profile, cal.Correct, cal.Labeled, tt.profile.Name, tt.correct)
+			}
+			if want := float64(tt.correct) / 3; cal.Accuracy != want {
+				t.Errorf("Accuracy = %g, want %g", cal.Accuracy, want)
+			}
+			if !reflect.DeepEqual(cal.Confusion, tt.confusion) {
+				t.Errorf("Confusion = %v, want %v", cal.Confusion, tt.confusion)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// PrintSignalCatalog prints the signal catalog as a table.
+func PrintSignalCatalog(w io.Writer, catalog []models.SignalInfo) {
+	fmt.Fprintf(w, "%-28s %6s  %-13s %s\n", "TYPE", "SCORE", "CATEGORY", "DESCRIPTION")
+	for _, info := range catalog {
+		fmt.Fprintf(w, "%-28s %+6d  %-13s %s\n", info.Type, info.Score, info.Category, info.Description)
+	}
+}
@@ -0,0 +1,25 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestPrintSignalCatalog(t *testing.T) {
+	var buf bytes.Buffer
+	PrintSignalCatalog(&buf, models.SignalCatalog)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(models.SignalCatalog)+1 {
+		t.Fatalf("got %d lines, want a header and one per signal (%d)", len(lines), len(models.SignalCatalog)+1)
+	}
+	for i, info := range models.SignalCatalog {
+		fields := strings.Fields(lines[i+1])
+		if len(fields) < 3 || fields[0] != info.Type || fields[2] != info.Category {
+			t.Errorf("line %d = %q, want %s first and %s third", i+1, lines[i+1], info.Type, info.Category)
+		}
+	}
+}
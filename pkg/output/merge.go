@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// ResultSet is the reconcilers loaded from one result file.
+type ResultSet struct {
+	Source      string
+	Reconcilers []models.Reconciler
+}
+
+// MergeConflict records a reconciler ID found in two result files with
+// differing content. The first copy is kept.
+type MergeConflict struct {
+	ID      string `json:"id"`
+	Kept    string `json:"kept"`    // source of the kept copy
+	Dropped string `json:"dropped"` // source of the differing copy
+}
+
+// Merge concatenates result sets, keeping the first reconciler seen for each
+// ID. Identical duplicates are dropped silently; duplicates whose content
+// differs are reported as conflicts; the tool version is not compared, so
+// results of different survey builds merge. Order of first appearance is
+// preserved.
+func Merge(sets []ResultSet) ([]models.Reconciler, []MergeConflict, error) {
+	type seen struct {
+		source  string
+		content []byte
+	}
+
+	byID := make(map[string]seen)
+	var merged []models.Reconciler
+	var conflicts []MergeConflict
+	for _, set := range sets {
+		for _, r := range set.Reconcilers {
+			unversioned := r
+			unversioned.ToolVersion = ""
+			content, err := json.Marshal(unversioned)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal reconciler %s: %w", r.ID, err)
+			}
+			if prev, ok := byID[r.ID]; ok {
+				if !bytes.Equal(prev.content, content) {
+					conflicts = append(conflicts, MergeConflict{ID: r.ID, Kept: prev.source, Dropped: set.Source})
+				}
+				continue
+			}
+			byID[r.ID] = seen{source: set.Source, content: content}
+			merged = append(merged, r)
+		}
+	}
+
+	return merged, conflicts, nil
+}
+
+// PrintConflicts lists merge conflicts, one per line.
+func PrintConflicts(w io.Writer, conflicts []MergeConflict) {
+	for _, c := range conflicts {
+		fmt.Fprintf(w, "conflict: %s differs between %s (kept) and %s\n", c.ID, c.Kept, c.Dropped)
+	}
+}
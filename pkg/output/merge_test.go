@@ -0,0 +1,60 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestMerge(t *testing.T) {
+	a := models.Reconciler{ID: "acme/widgets#a.go#10", Score: 2, ToolVersion: "v1.0.0"}
+	b := models.Reconciler{ID: "acme/widgets#b.go#20", Score: -1, ToolVersion: "v1.0.0"}
+	rescored := a
+	rescored.Score = 3
+	rebuilt := a
+	rebuilt.ToolVersion = "v1.1.0"
+
+	tests := []struct {
+		name      string
+		sets      []ResultSet
+		want      []models.Reconciler
+		conflicts []MergeConflict
+	}{
+		{
+			name: "disjoint",
+			sets: []ResultSet{{Source: "1.jsonl", Reconcilers: []models.Reconciler{a}}, {Source: "2.jsonl", Reconcilers: []models.Reconciler{b}}},
+			want: []models.Reconciler{a, b},
+		},
+		{
+			name: "identical duplicate",
+			sets: []ResultSet{{Source: "1.jsonl", Reconcilers: []models.Reconciler{a, b}}, {Source: "2.jsonl", Reconcilers: []models.Reconciler{a}}},
+			want: []models.Reconciler{a, b},
+		},
+		{
+			name: "different tool version",
+			sets: []ResultSet{{Source: "1.jsonl", Reconcilers: []models.Reconciler{a}}, {Source: "2.jsonl", Reconcilers: []models.Reconciler{rebuilt}}},
+			want: []models.Reconciler{a},
+		},
+		{
+			name:      "conflict",
+			sets:      []ResultSet{{Source: "1.jsonl", Reconcilers: []models.Reconciler{a}}, {Source: "2.jsonl", Reconcilers: []models.Reconciler{b, rescored}}},
+			want:      []models.Reconciler{a, b},
+			conflicts: []MergeConflict{{ID: a.ID, Kept: "1.jsonl", Dropped: "2.jsonl"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts, err := Merge(tt.sets)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("merged = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(conflicts, tt.conflicts) {
+				t.Errorf("conflicts = %+v, want %+v", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
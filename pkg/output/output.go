@@ -1,48 +1,155 @@
 package output
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/rg0now/k8s-controller-survey/pkg/models"
 )
 
 // Writer handles output of analysis results.
+//
+// A Writer either streams all results to a single file (or stdout), or,
+// when created with NewDirWriter, writes one JSONL file per repository.
+// Output can optionally be gzip-compressed. Writers are safe for concurrent use.
 type Writer struct {
-	file   *os.File
-	writer io.Writer
+	mu       sync.Mutex
+	file     *os.File
+	gz       *gzip.Writer
+	writer   io.Writer
+	dir      string
+	compress bool
+
+	// ExplodeSignals writes one models.SignalRecord line per signal instead
+	// of one line per reconciler.
+	ExplodeSignals bool
+}
+
+// NewWriter creates a new output writer. Output is gzip-compressed if
+// compress is set or path ends in ".gz".
+func NewWriter(path string, compress bool) (*Writer, error) {
+	compress = compress || strings.HasSuffix(path, ".gz")
+
+	w := &Writer{writer: os.Stdout, compress: compress}
+	if path != "" && path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create output file: %w", err)
+		}
+		w.file = file
+		w.writer = file
+	}
+
+	if compress {
+		w.gz = gzip.NewWriter(w.writer)
+		w.writer = w.gz
+	}
+
+	return w, nil
 }
 
-// NewWriter creates a new output writer.
-func NewWriter(path string) (*Writer, error) {
-	if path == "" || path == "-" {
-		return &Writer{
-			file:   nil,
-			writer: os.Stdout,
-		}, nil
+// NewDirWriter creates a writer that stores each repository's results in
+// its own owner__name.jsonl (or .jsonl.gz if compress is set) file under dir.
+func NewDirWriter(dir string, compress bool) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	file, err := os.Create(path)
+	return &Writer{dir: dir, compress: compress}, nil
+}
+
+// RepoFileName returns the per-repo results file name used by directory writers.
+func RepoFileName(repo models.Repository) string {
+	return fmt.Sprintf("%s__%s.jsonl", repo.Owner, repo.Name)
+}
+
+// WriteRepo writes the results of one repository. In directory mode, the
+// repo's file is (re)created even if there are no reconcilers, so that
+// processed repos can be told apart from skipped ones.
+func (w *Writer) WriteRepo(repo models.Repository, reconcilers []models.Reconciler) error {
+	if w.dir == "" {
+		return w.WriteReconcilers(reconcilers)
+	}
+
+	name := RepoFileName(repo)
+	if w.compress {
+		name += ".gz"
+	}
+
+	file, err := os.Create(filepath.Join(w.dir, name))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create output file: %w", err)
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	var out io.Writer = file
+	var gz *gzip.Writer
+	if w.compress {
+		gz = gzip.NewWriter(file)
+		out = gz
 	}
 
-	return &Writer{
-		file:   file,
-		writer: file,
-	}, nil
+	if err := w.writeReconcilers(out, reconcilers); err != nil {
+		file.Close()
+		return err
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to flush compressed output: %w", err)
+		}
+	}
+
+	return file.Close()
 }
 
 // WriteReconciler writes a single reconciler as a JSON line.
 func (w *Writer) WriteReconciler(r models.Reconciler) error {
-	data, err := json.Marshal(r)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writeReconciler(w.writer, r)
+}
+
+// WriteReconcilers writes multiple reconcilers as JSON lines.
+func (w *Writer) WriteReconcilers(reconcilers []models.Reconciler) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writeReconcilers(w.writer, reconcilers)
+}
+
+// writeReconciler writes a single reconciler as a JSON line to out, or one
+// line per signal with ExplodeSignals.
+func (w *Writer) writeReconciler(out io.Writer, r models.Reconciler) error {
+	if w.ExplodeSignals {
+		for _, rec := range ExplodeSignals(r) {
+			if err := writeLine(out, rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return writeLine(out, r)
+}
+
+// writeLine writes v as a JSON line to out.
+func writeLine(out io.Writer, v any) error {
+	data, err := json.Marshal(v)
 	if err != nil {
-		return fmt.Errorf("failed to marshal reconciler: %w", err)
+		return fmt.Errorf("failed to marshal record: %w", err)
 	}
 
-	_, err = fmt.Fprintf(w.writer, "%s\n", data)
+	_, err = fmt.Fprintf(out, "%s\n", data)
 	if err != nil {
 		return fmt.Errorf("failed to write output: %w", err)
 	}
@@ -50,101 +157,329 @@ func (w *Writer) WriteReconciler(r models.Reconciler) error {
 	return nil
 }
 
-// WriteReconcilers writes multiple reconcilers as JSON lines.
-func (w *Writer) WriteReconcilers(reconcilers []models.Reconciler) error {
+// ExplodeSignals flattens a reconciler into one record per signal, each
+// carrying the reconciler's score and classification. A reconciler without
+// signals yields a single record with an empty signal, so none is lost.
+func ExplodeSignals(r models.Reconciler) []models.SignalRecord {
+	signals := r.Signals
+	if len(signals) == 0 {
+		signals = []models.Signal{{}}
+	}
+	records := make([]models.SignalRecord, 0, len(signals))
+	for _, sig := range signals {
+		records = append(records, models.SignalRecord{
+			ReconcilerID:    r.ID,
+			Repo:            r.Repo,
+			File:            r.File,
+			ReconcilerScore: r.Score,
+			Classification:  r.Classification,
+			SignalFile:      sig.File,
+			Signal:          sig,
+		})
+	}
+	return records
+}
+
+// writeReconcilers writes multiple reconcilers as JSON lines to out.
+func (w *Writer) writeReconcilers(out io.Writer, reconcilers []models.Reconciler) error {
 	for _, r := range reconcilers {
-		if err := w.WriteReconciler(r); err != nil {
+		if err := w.writeReconciler(out, r); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// Close closes the output file if it was opened.
+// Close flushes compressed output and closes the output file if it was opened.
 func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			if w.file != nil {
+				w.file.Close()
+			}
+			return fmt.Errorf("failed to flush compressed output: %w", err)
+		}
+	}
 	if w.file != nil {
 		return w.file.Close()
 	}
 	return nil
 }
 
+// OpenResults opens a JSONL results file for reading, transparently
+// decompressing gzip input (detected by its magic bytes).
+func OpenResults(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(file)
+	magic, err := br.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		// Not gzip (or too short to tell): read as plain text.
+		return readCloser{Reader: br, Closer: file}, nil
+	}
+
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open gzip input: %w", err)
+	}
+
+	return readCloser{Reader: gz, Closer: multiCloser{gz, file}}, nil
+}
+
+// readCloser pairs a Reader with the Closer of its underlying file.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// multiCloser closes several closers in order, returning the first error.
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+	var first error
+	for _, c := range m {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
 // Summary represents analysis summary statistics.
 type Summary struct {
-	TotalReconcilers int                 `json:"total_reconcilers"`
-	ByClassification map[string]int      `json:"by_classification"`
-	ByRepo           map[string]int      `json:"by_repo"`
-	SignalFrequency  map[string]int      `json:"signal_frequency"`
-	AverageScore     float64             `json:"average_score"`
-	TopSoTW          []models.Reconciler `json:"top_sotw,omitempty"`
-	TopEdge          []models.Reconciler `json:"top_edge,omitempty"`
+	TotalReconcilers int                       `json:"total_reconcilers"`
+	ByClassification map[string]int            `json:"by_classification"`
+	ByRepo           map[string]int            `json:"by_repo"`
+	SignalFrequency  map[string]int            `json:"signal_frequency"`
+	Cooccurrence     map[string]map[string]int `json:"signal_cooccurrence,omitempty"` // type -> type -> reconcilers with both; symmetric
+	AverageScore     float64                   `json:"average_score"`
+	ReadOnly         int                       `json:"read_only"`                 // reconcilers with no client writes
+	ByPrimaryType    map[string]map[string]int `json:"by_primary_type,omitempty"` // type -> classification -> count
+	ByWatchedType    map[string]map[string]int `json:"by_watched_type,omitempty"` // type -> classification -> count
+	TopSoTW          []models.Reconciler       `json:"top_sotw,omitempty"`
+	TopEdge          []models.Reconciler       `json:"top_edge,omitempty"`
+	Repos            []RepoRollup              `json:"repos,omitempty"`
+	WallClock        time.Duration             `json:"wall_clock_ns,omitempty"`
+	SlowestRepos     []models.RepoTiming       `json:"slowest_repos,omitempty"`
+	NeedsReview      []models.RepoInventory    `json:"needs_review,omitempty"`
 }
 
-// GenerateSummary generates a summary from a list of reconcilers.
-func GenerateSummary(reconcilers []models.Reconciler, topN int) Summary {
-	summary := Summary{
-		TotalReconcilers: len(reconcilers),
-		ByClassification: make(map[string]int),
-		ByRepo:           make(map[string]int),
-		SignalFrequency:  make(map[string]int),
+// RepoRollup is the aggregate verdict for a single repository.
+type RepoRollup struct {
+	Repo             string         `json:"repo"`
+	Reconcilers      int            `json:"reconcilers"`
+	AverageScore     float64        `json:"average_score"`
+	ByClassification map[string]int `json:"by_classification"`
+	Classification   string         `json:"classification"`
+}
+
+// repoVerdict picks the rollup classification: the majority classification
+// among the repo's reconcilers, with ties broken by classifying the repo's
+// average score instead.
+func repoVerdict(rollup RepoRollup) string {
+	best, bestCount, tied := "", 0, false
+	for class, count := range rollup.ByClassification {
+		switch {
+		case count > bestCount:
+			best, bestCount, tied = class, count, false
+		case count == bestCount:
+			tied = true
+		}
 	}
+	if tied {
+		best = models.DefaultProfile().ClassifyScore(rollup.AverageScore)
+	}
+	return best
+}
 
-	totalScore := 0
-	for _, r := range reconcilers {
-		summary.ByClassification[r.Classification]++
-		summary.ByRepo[r.Repo]++
-		totalScore += r.Score
+// AddTimings records the total wall-clock time and the topN slowest repos.
+func (s *Summary) AddTimings(timings []models.RepoTiming, wallClock time.Duration, topN int) {
+	s.WallClock = wallClock
+
+	sorted := make([]models.RepoTiming, len(timings))
+	copy(sorted, timings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Total() > sorted[j].Total()
+	})
+
+	if topN > len(sorted) {
+		topN = len(sorted)
+	}
+	s.SlowestRepos = sorted[:topN]
+}
+
+// timingReport is the on-disk format of the timing sidecar file.
+type timingReport struct {
+	WallClock time.Duration       `json:"wall_clock_ns"`
+	Repos     []models.RepoTiming `json:"repos"`
+}
+
+// WriteTimings writes per-repo timings as a JSON document to path.
+func WriteTimings(path string, timings []models.RepoTiming, wallClock time.Duration) error {
+	data, err := json.MarshalIndent(timingReport{WallClock: wallClock, Repos: timings}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal timings: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write timings: %w", err)
+	}
 
-		for _, sig := range r.Signals {
-			summary.SignalFrequency[sig.Type]++
+	return nil
+}
+
+// AddCoverage records the repositories whose coverage needs manual review.
+func (s *Summary) AddCoverage(coverage []models.RepoInventory) {
+	s.NeedsReview = nil
+	for _, inv := range coverage {
+		if inv.NeedsReview() {
+			s.NeedsReview = append(s.NeedsReview, inv)
 		}
 	}
+	sort.Slice(s.NeedsReview, func(i, j int) bool {
+		return s.NeedsReview[i].Repo < s.NeedsReview[j].Repo
+	})
+}
 
-	if len(reconcilers) > 0 {
-		summary.AverageScore = float64(totalScore) / float64(len(reconcilers))
+// WriteCoverage writes per-repo coverage records as a JSON array to path.
+func WriteCoverage(path string, coverage []models.RepoInventory) error {
+	data, err := json.MarshalIndent(coverage, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal coverage: %w", err)
 	}
 
-	// Get top SoTW and edge-triggered reconcilers.
-	if topN > 0 {
-		summary.TopSoTW = getTopByScore(reconcilers, topN, true)
-		summary.TopEdge = getTopByScore(reconcilers, topN, false)
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write coverage: %w", err)
 	}
 
-	return summary
+	return nil
 }
 
-// getTopByScore gets the top N reconcilers by score (highest or lowest).
-func getTopByScore(reconcilers []models.Reconciler, n int, highest bool) []models.Reconciler {
-	// Simple selection - could be optimized with heap.
-	sorted := make([]models.Reconciler, len(reconcilers))
-	copy(sorted, reconcilers)
+// WriteErrors writes repository failures to path as JSONL, one record per
+// line, so failed repositories can be post-processed or retried.
+func WriteErrors(path string, errs []models.RepoError) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range errs {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to marshal error record: %w", err)
+		}
+	}
 
-	// Bubble sort for simplicity (good enough for small N).
-	for i := 0; i < len(sorted); i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			if highest {
-				if sorted[j].Score > sorted[i].Score {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			} else {
-				if sorted[j].Score < sorted[i].Score {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write errors: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateSummary generates a summary from a list of reconcilers.
+func GenerateSummary(reconcilers []models.Reconciler, topN int) Summary {
+	acc := NewSummaryAccumulator(topN)
+	for _, r := range reconcilers {
+		acc.Add(r)
+	}
+	return acc.Finalize()
+}
+
+// ExcludePackages returns the reconcilers whose ReceiverPkg does not match
+// re, e.g. to leave vendored or API-package reconcilers out of a report.
+func ExcludePackages(reconcilers []models.Reconciler, re *regexp.Regexp) []models.Reconciler {
+	var kept []models.Reconciler
+	for _, r := range reconcilers {
+		if !re.MatchString(r.ReceiverPkg) {
+			kept = append(kept, r)
 		}
 	}
+	return kept
+}
 
-	if n > len(sorted) {
-		n = len(sorted)
+// PrintSummaryJSON prints a summary as indented JSON to the given writer.
+func PrintSummaryJSON(w io.Writer, summary Summary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(summary); err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
 	}
+	return nil
+}
 
-	return sorted[:n]
+// TypeCount is the number of reconcilers associated with a Kubernetes type,
+// and how many of them classify as mostly_sotw or sotw.
+type TypeCount struct {
+	Type  string
+	Total int
+	SoTW  int
+}
+
+// rankTypes orders a type -> classification -> count aggregation by total
+// count, then by SoTW count, then by type.
+func rankTypes(byType map[string]map[string]int) []TypeCount {
+	counts := make([]TypeCount, 0, len(byType))
+	for t, byClass := range byType {
+		tc := TypeCount{Type: t}
+		for class, n := range byClass {
+			tc.Total += n
+			if class == "mostly_sotw" || class == "sotw" {
+				tc.SoTW += n
+			}
+		}
+		counts = append(counts, tc)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Total != counts[j].Total {
+			return counts[i].Total > counts[j].Total
+		}
+		if counts[i].SoTW != counts[j].SoTW {
+			return counts[i].SoTW > counts[j].SoTW
+		}
+		return counts[i].Type < counts[j].Type
+	})
+	return counts
+}
+
+// SignalPair is the number of reconcilers exhibiting both signal types A and B.
+type SignalPair struct {
+	A, B  string
+	Count int
+}
+
+// rankPairs lists each pair of a co-occurrence matrix once, A before B,
+// ordered by count, then by types.
+func rankPairs(cooccurrence map[string]map[string]int) []SignalPair {
+	var pairs []SignalPair
+	for a, row := range cooccurrence {
+		for b, n := range row {
+			if a < b {
+				pairs = append(pairs, SignalPair{A: a, B: b, Count: n})
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Count != pairs[j].Count {
+			return pairs[i].Count > pairs[j].Count
+		}
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
 }
 
 // PrintSummary prints a summary to the given writer.
 func PrintSummary(w io.Writer, summary Summary) {
 	fmt.Fprintf(w, "=== Analysis Summary ===\n\n")
 	fmt.Fprintf(w, "Total Reconcilers: %d\n", summary.TotalReconcilers)
-	fmt.Fprintf(w, "Average Score: %.2f\n\n", summary.AverageScore)
+	fmt.Fprintf(w, "Average Score: %.2f\n", summary.AverageScore)
+	fmt.Fprintf(w, "Read-only Reconcilers: %d\n\n", summary.ReadOnly)
 
 	fmt.Fprintf(w, "Classification Distribution:\n")
 	for class, count := range summary.ByClassification {
@@ -176,6 +511,28 @@ func PrintSummary(w io.Writer, summary Summary) {
 	}
 	fmt.Fprintf(w, "\n")
 
+	if pairs := rankPairs(summary.Cooccurrence); len(pairs) > 0 {
+		fmt.Fprintf(w, "Top Signal Co-occurrences:\n")
+		for i, p := range pairs {
+			if i == 10 {
+				break
+			}
+			fmt.Fprintf(w, "  %s + %s: %d\n", p.A, p.B, p.Count)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	if len(summary.ByWatchedType) > 0 {
+		fmt.Fprintf(w, "Top Watched Types:\n")
+		for i, tc := range rankTypes(summary.ByWatchedType) {
+			if i == 10 {
+				break
+			}
+			fmt.Fprintf(w, "  %s: %d reconcilers (%d SoTW)\n", tc.Type, tc.Total, tc.SoTW)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
 	if len(summary.TopSoTW) > 0 {
 		fmt.Fprintf(w, "Top SoTW Reconcilers:\n")
 		for i, r := range summary.TopSoTW {
@@ -191,4 +548,39 @@ func PrintSummary(w io.Writer, summary Summary) {
 		}
 		fmt.Fprintf(w, "\n")
 	}
+
+	if len(summary.Repos) > 0 {
+		fmt.Fprintf(w, "Repository Verdicts:\n")
+		for _, r := range summary.Repos {
+			fmt.Fprintf(w, "  %s: %s (%d reconcilers, avg score: %.2f)\n",
+				r.Repo, r.Classification, r.Reconcilers, r.AverageScore)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	if summary.WallClock > 0 {
+		fmt.Fprintf(w, "Total Wall-Clock: %s\n\n", summary.WallClock.Round(time.Millisecond))
+	}
+
+	if len(summary.SlowestRepos) > 0 {
+		fmt.Fprintf(w, "Slowest Repositories:\n")
+		for i, t := range summary.SlowestRepos {
+			fmt.Fprintf(w, "  %d. %s (clone: %s, analyze: %s)\n", i+1, t.Repo,
+				t.Clone.Round(time.Millisecond), t.Analyze.Round(time.Millisecond))
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	if len(summary.NeedsReview) > 0 {
+		fmt.Fprintf(w, "Repositories Needing Review:\n")
+		for _, inv := range summary.NeedsReview {
+			fmt.Fprintf(w, "  %s: %d/%d packages loaded (%s), %d Reconcile functions", inv.Repo,
+				inv.Packages-inv.PackageErrors, inv.Packages, inv.LoadQuality, inv.Reconcilers)
+			if inv.AnalysisErrors > 0 {
+				fmt.Fprintf(w, " (%d failed to analyze)", inv.AnalysisErrors)
+			}
+			fmt.Fprintf(w, "\n")
+		}
+		fmt.Fprintf(w, "\n")
+	}
 }
@@ -0,0 +1,366 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// nestSignals rebuilds the reconciler-level fields ExplodeSignals carries
+// from its records, in first-seen order.
+func nestSignals(records []models.SignalRecord) []models.Reconciler {
+	var reconcilers []models.Reconciler
+	index := make(map[string]int)
+	for _, rec := range records {
+		i, ok := index[rec.ReconcilerID]
+		if !ok {
+			i = len(reconcilers)
+			index[rec.ReconcilerID] = i
+			reconcilers = append(reconcilers, models.Reconciler{
+				ID:             rec.ReconcilerID,
+				Repo:           rec.Repo,
+				File:           rec.File,
+				Score:          rec.ReconcilerScore,
+				Classification: rec.Classification,
+			})
+		}
+		if rec.Type == "" {
+			continue
+		}
+		sig := rec.Signal
+		sig.File = rec.SignalFile
+		reconcilers[i].Signals = append(reconcilers[i].Signals, sig)
+	}
+	return reconcilers
+}
+
+func TestExplodeSignalsRoundTrip(t *testing.T) {
+	reconcilers := loadFixture(t, "results.jsonl")
+	// A setup signal from another file must keep its own file.
+	reconcilers[0].Signals[1].File = "controllers/setup.go"
+
+	w, err := NewWriter(filepath.Join(t.TempDir(), "signals.jsonl"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.ExplodeSignals = true
+	var buf bytes.Buffer
+	if err := w.writeReconcilers(&buf, reconcilers); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	var records []models.SignalRecord
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var rec models.SignalRecord
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rec)
+	}
+	if want := 3 + 2 + 1; len(records) != want {
+		t.Fatalf("got %d records, want %d (one per signal, one for the signal-less reconciler)", len(records), want)
+	}
+
+	// Only the fields records carry survive the round trip.
+	var want []models.Reconciler
+	for _, r := range reconcilers {
+		want = append(want, models.Reconciler{
+			ID:             r.ID,
+			Repo:           r.Repo,
+			File:           r.File,
+			Score:          r.Score,
+			Classification: r.Classification,
+			Signals:        r.Signals,
+		})
+	}
+	if got := nestSignals(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestRepoVerdict(t *testing.T) {
+	tests := []struct {
+		name   string
+		rollup RepoRollup
+		want   string
+	}{
+		{
+			name:   "majority",
+			rollup: RepoRollup{AverageScore: 5, ByClassification: map[string]int{"edge_triggered": 2, "sotw": 1}},
+			want:   "edge_triggered",
+		},
+		{
+			name:   "tie broken by average score",
+			rollup: RepoRollup{AverageScore: 1, ByClassification: map[string]int{"edge_triggered": 1, "sotw": 1}},
+			want:   "mostly_sotw",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repoVerdict(tt.rollup); got != tt.want {
+				t.Errorf("repoVerdict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddTimings(t *testing.T) {
+	timings := []models.RepoTiming{
+		{Repo: "a", Clone: 1 * time.Second, Analyze: 1 * time.Second},
+		{Repo: "b", Clone: 5 * time.Second},
+		{Repo: "c", Analyze: 3 * time.Second},
+		{Repo: "d", Clone: 1 * time.Second, Analyze: 1 * time.Second},
+	}
+	tests := []struct {
+		name string
+		topN int
+		want []string
+	}{
+		{name: "slowest first, ties in input order", topN: 3, want: []string{"b", "c", "a"}},
+		{name: "topN beyond the repos", topN: 10, want: []string{"b", "c", "a", "d"}},
+		{name: "none", topN: 0, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Summary
+			s.AddTimings(timings, time.Minute, tt.topN)
+			got := []string{}
+			for _, timing := range s.SlowestRepos {
+				got = append(got, timing.Repo)
+			}
+			if s.WallClock != time.Minute || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddTimings() = %s %v, want 1m0s %v", s.WallClock, got, tt.want)
+			}
+		})
+	}
+	if timings[0].Repo != "a" || timings[1].Repo != "b" {
+		t.Error("AddTimings reordered its input")
+	}
+}
+
+func TestWriteTimings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timings.json")
+	timings := []models.RepoTiming{{Repo: "a", Clone: time.Second, Analyze: 2 * time.Second}}
+	if err := WriteTimings(path, timings, 3*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report timingReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.WallClock != 3*time.Second || !reflect.DeepEqual(report.Repos, timings) {
+		t.Errorf("WriteTimings() wrote %+v", report)
+	}
+}
+
+func TestAddCoverage(t *testing.T) {
+	coverage := []models.RepoInventory{
+		{Repo: "c", Reconcilers: 1, LoadQuality: models.LoadQualityPartial},
+		{Repo: "a", Reconcilers: 1, LoadQuality: models.LoadQualityFull},
+		{Repo: "b", LoadQuality: models.LoadQualityFull},
+	}
+	var s Summary
+	s.NeedsReview = []models.RepoInventory{{Repo: "stale"}}
+	s.AddCoverage(coverage)
+	var got []string
+	for _, inv := range s.NeedsReview {
+		got = append(got, inv.Repo)
+	}
+	if want := []string{"b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AddCoverage() needs review = %v, want %v", got, want)
+	}
+}
+
+func TestWriteCoverage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.json")
+	coverage := []models.RepoInventory{{Repo: "a", Packages: 2, Reconcilers: 1, LoadQuality: models.LoadQualityFull}}
+	if err := WriteCoverage(path, coverage); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []models.RepoInventory
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, coverage) {
+		t.Errorf("WriteCoverage() wrote %+v, want %+v", got, coverage)
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []models.RepoError
+	}{
+		{
+			name: "one record per failure",
+			errs: []models.RepoError{
+				{Repo: "https://github.com/acme/widgets", Phase: models.PhaseClone, Error: "repository not found"},
+				{Repo: "https://github.com/other/gadgets", Phase: models.PhaseAnalyze, Error: "no go.mod"},
+			},
+		},
+		{name: "no failures"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "errors.jsonl")
+			if err := WriteErrors(path, tt.errs); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []models.RepoError
+			dec := json.NewDecoder(bytes.NewReader(data))
+			for dec.More() {
+				var e models.RepoError
+				if err := dec.Decode(&e); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, e)
+			}
+			if lines := bytes.Count(data, []byte("\n")); lines != len(tt.errs) || !reflect.DeepEqual(got, tt.errs) {
+				t.Errorf("WriteErrors() wrote %d lines %+v, want %+v", lines, got, tt.errs)
+			}
+		})
+	}
+}
+
+func TestExcludePackages(t *testing.T) {
+	reconcilers := []models.Reconciler{
+		{ID: "a", ReceiverPkg: "example.com/op/controllers"},
+		{ID: "b", ReceiverPkg: "example.com/op/vendor/other.io/op/controllers"},
+		{ID: "c", ReceiverPkg: "example.com/op/api/v1"},
+	}
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"/vendor/|/api/", []string{"a"}},
+		{"^other.io/", []string{"a", "b", "c"}},
+		{"example.com/op", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, r := range ExcludePackages(reconcilers, regexp.MustCompile(tt.pattern)) {
+			got = append(got, r.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExcludePackages(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+// readResults reads the reconcilers of a (possibly gzipped) results file.
+func readResults(t *testing.T, path string) []models.Reconciler {
+	t.Helper()
+	in, err := OpenResults(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	var reconcilers []models.Reconciler
+	dec := json.NewDecoder(in)
+	for dec.More() {
+		var r models.Reconciler
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("failed to parse %s: %v", path, err)
+		}
+		reconcilers = append(reconcilers, r)
+	}
+	return reconcilers
+}
+
+func TestDirWriter(t *testing.T) {
+	reconcilers := loadFixture(t, "results.jsonl")
+	repos := []struct {
+		repo        models.Repository
+		reconcilers []models.Reconciler
+	}{
+		{models.Repository{Owner: "acme", Name: "widgets"}, reconcilers[:2]},
+		{models.Repository{Owner: "other", Name: "empty"}, nil},
+	}
+
+	dir := filepath.Join(t.TempDir(), "results")
+	w, err := NewDirWriter(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range repos {
+		if err := w.WriteRepo(r.repo, r.reconcilers); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range repos {
+		path := filepath.Join(dir, RepoFileName(r.repo))
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s: %v (a processed repo must get a file even without reconcilers)", r.repo.Name, err)
+			continue
+		}
+		if got := readResults(t, path); !reflect.DeepEqual(got, r.reconcilers) {
+			t.Errorf("%s: read back %+v, want %+v", r.repo.Name, got, r.reconcilers)
+		}
+	}
+}
+
+func TestCompressedResults(t *testing.T) {
+	reconcilers := loadFixture(t, "results.jsonl")
+	tests := []struct {
+		name     string
+		file     string
+		compress bool
+		gzipped  bool
+	}{
+		{name: "plain", file: "results.jsonl"},
+		{name: "flag", file: "results.jsonl", compress: true, gzipped: true},
+		{name: "extension", file: "results.jsonl.gz", gzipped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			w, err := NewWriter(path, tt.compress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.WriteReconcilers(reconcilers); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gzipped := len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b; gzipped != tt.gzipped {
+				t.Errorf("gzipped = %t, want %t", gzipped, tt.gzipped)
+			}
+			if got := readResults(t, path); !reflect.DeepEqual(got, reconcilers) {
+				t.Errorf("read back %d reconcilers that differ from the %d written", len(got), len(reconcilers))
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// DefaultLinkBase is the permalink base used when --link-base is given
+// without a value.
+const DefaultLinkBase = "https://github.com"
+
+// Permalink returns the blob URL of a signal's line under base, e.g.
+// https://github.com/owner/name/blob/<ref>/path/file.go#L42, in the signal's
+// own file if it has one, else the reconciler's. GitLab hosts use their
+// /-/blob/ layout. An empty ref links to HEAD.
+func Permalink(base, ref string, r models.Reconciler, sig models.Signal) string {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	blob := "blob"
+	if isGitLab(base) {
+		blob = "-/blob"
+	}
+	file := r.File
+	if sig.File != "" {
+		file = sig.File
+	}
+	return fmt.Sprintf("%s/%s/%s/%s/%s#L%d", strings.TrimSuffix(base, "/"), r.Repo, blob, ref, file, sig.Line)
+}
+
+// isGitLab reports whether base is on a GitLab host: gitlab.com or a
+// self-hosted gitlab.* instance.
+func isGitLab(base string) bool {
+	u, err := url.Parse(base)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	return host == "gitlab.com" || strings.HasPrefix(host, "gitlab.")
+}
+
+// LinkBase returns the permalink base for a repository: base itself, unless
+// base is DefaultLinkBase and the repository lives on another host.
+func LinkBase(base string, repo models.Repository) string {
+	if base != DefaultLinkBase {
+		return base
+	}
+	u, err := url.Parse(repo.URL)
+	if err != nil || u.Host == "" || u.Host == "github.com" || (u.Scheme != "http" && u.Scheme != "https") {
+		return base
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// AddPermalinks sets the Permalink of every signal of reconcilers.
+func AddPermalinks(reconcilers []models.Reconciler, base, ref string) {
+	for i := range reconcilers {
+		for j := range reconcilers[i].Signals {
+			sig := &reconcilers[i].Signals[j]
+			sig.Permalink = Permalink(base, ref, reconcilers[i], *sig)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+func TestPermalink(t *testing.T) {
+	r := models.Reconciler{Repo: "acme/widgets", File: "controllers/widget_controller.go"}
+
+	tests := []struct {
+		name string
+		base string
+		ref  string
+		sig  models.Signal
+		want string
+	}{
+		{
+			name: "github",
+			base: "https://github.com",
+			ref:  "abc123",
+			sig:  models.Signal{Line: 42},
+			want: "https://github.com/acme/widgets/blob/abc123/controllers/widget_controller.go#L42",
+		},
+		{
+			name: "empty ref links to HEAD",
+			base: "https://github.com/",
+			sig:  models.Signal{Line: 7},
+			want: "https://github.com/acme/widgets/blob/HEAD/controllers/widget_controller.go#L7",
+		},
+		{
+			name: "signal in another file",
+			base: "https://github.com",
+			ref:  "abc123",
+			sig:  models.Signal{Line: 12, File: "controllers/setup.go"},
+			want: "https://github.com/acme/widgets/blob/abc123/controllers/setup.go#L12",
+		},
+		{
+			name: "gitlab.com",
+			base: "https://gitlab.com",
+			ref:  "abc123",
+			sig:  models.Signal{Line: 42},
+			want: "https://gitlab.com/acme/widgets/-/blob/abc123/controllers/widget_controller.go#L42",
+		},
+		{
+			name: "self-hosted gitlab",
+			base: "https://gitlab.example.com",
+			ref:  "abc123",
+			sig:  models.Signal{Line: 42},
+			want: "https://gitlab.example.com/acme/widgets/-/blob/abc123/controllers/widget_controller.go#L42",
+		},
+		{
+			name: "gitlab only in the path",
+			base: "https://git.example.com/gitlab-mirror",
+			ref:  "abc123",
+			sig:  models.Signal{Line: 42},
+			want: "https://git.example.com/gitlab-mirror/acme/widgets/blob/abc123/controllers/widget_controller.go#L42",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Permalink(tt.base, tt.ref, r, tt.sig); got != tt.want {
+				t.Errorf("Permalink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkBase(t *testing.T) {
+	tests := []struct {
+		base string
+		url  string
+		want string
+	}{
+		{DefaultLinkBase, "https://github.com/acme/widgets", DefaultLinkBase},
+		{DefaultLinkBase, "https://gitlab.com/acme/widgets", "https://gitlab.com"},
+		{DefaultLinkBase, "git@github.com:acme/widgets.git", DefaultLinkBase},
+		{"https://git.example.com", "https://gitlab.com/acme/widgets", "https://git.example.com"},
+	}
+	for _, tt := range tests {
+		if got := LinkBase(tt.base, models.Repository{URL: tt.url}); got != tt.want {
+			t.Errorf("LinkBase(%q, %q) = %q, want %q", tt.base, tt.url, got, tt.want)
+		}
+	}
+}
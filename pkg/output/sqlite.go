@@ -0,0 +1,95 @@
+package output
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// sqliteSchema creates the tables ExportSQLite fills: one row per
+// reconciler, and one per signal linked to its reconciler by ID.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS reconcilers (
+	id             TEXT PRIMARY KEY,
+	repo           TEXT NOT NULL,
+	owner          TEXT NOT NULL,
+	file           TEXT NOT NULL,
+	line           INTEGER NOT NULL,
+	end_line       INTEGER NOT NULL,
+	receiver_type  TEXT NOT NULL,
+	receiver_pkg   TEXT NOT NULL,
+	score          INTEGER NOT NULL,
+	classification TEXT NOT NULL,
+	label          TEXT,
+	primary_type   TEXT,
+	direction      TEXT,
+	read_only      BOOLEAN NOT NULL,
+	has_finalizer  BOOLEAN NOT NULL,
+	load_quality   TEXT
+);
+CREATE TABLE IF NOT EXISTS signals (
+	reconciler_id TEXT NOT NULL REFERENCES reconcilers(id),
+	type          TEXT NOT NULL,
+	line          INTEGER NOT NULL,
+	score         INTEGER NOT NULL,
+	object_kind   TEXT,
+	snippet       TEXT,
+	description   TEXT
+);
+CREATE INDEX IF NOT EXISTS signals_reconciler ON signals(reconciler_id);
+CREATE INDEX IF NOT EXISTS signals_type ON signals(type);
+`
+
+// ExportSQLite writes reconcilers and their signals into db, creating the
+// reconcilers and signals tables if needed, in a single transaction.
+// Reconciler IDs must be unique; run results through Merge first.
+func ExportSQLite(db *sql.DB, reconcilers []models.Reconciler) error {
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	insertReconciler, err := tx.Prepare(`INSERT INTO reconcilers
+		(id, repo, owner, file, line, end_line, receiver_type, receiver_pkg, score, classification,
+		 label, primary_type, direction, read_only, has_finalizer, load_quality)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare reconciler insert: %w", err)
+	}
+	defer insertReconciler.Close()
+
+	insertSignal, err := tx.Prepare(`INSERT INTO signals
+		(reconciler_id, type, line, score, object_kind, snippet, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare signal insert: %w", err)
+	}
+	defer insertSignal.Close()
+
+	for _, r := range reconcilers {
+		owner, _, _ := strings.Cut(r.Repo, "/")
+		if _, err := insertReconciler.Exec(r.ID, r.Repo, owner, r.File, r.Line, r.EndLine,
+			r.ReceiverType, r.ReceiverPkg, r.Score, r.Classification,
+			r.Label, r.PrimaryType, r.Direction, r.ReadOnly, r.HasFinalizer, r.LoadQuality); err != nil {
+			return fmt.Errorf("failed to insert reconciler %s: %w", r.ID, err)
+		}
+		for _, sig := range r.Signals {
+			if _, err := insertSignal.Exec(r.ID, sig.Type, sig.Line, sig.Score,
+				sig.ObjectKind, sig.Snippet, sig.Description); err != nil {
+				return fmt.Errorf("failed to insert signal of %s: %w", r.ID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit export: %w", err)
+	}
+	return nil
+}
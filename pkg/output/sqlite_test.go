@@ -0,0 +1,85 @@
+package output
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+	_ "modernc.org/sqlite"
+)
+
+// loadFixture reads the reconcilers of a JSONL file under testdata.
+func loadFixture(t *testing.T, name string) []models.Reconciler {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var reconcilers []models.Reconciler
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var r models.Reconciler
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("failed to parse %s: %v", name, err)
+		}
+		reconcilers = append(reconcilers, r)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return reconcilers
+}
+
+func TestExportSQLite(t *testing.T) {
+	reconcilers := loadFixture(t, "results.jsonl")
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "survey.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := ExportSQLite(db, reconcilers); err != nil {
+		t.Fatalf("ExportSQLite: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"SELECT COUNT(*) FROM reconcilers", 3},
+		{"SELECT COUNT(*) FROM signals", 5},
+		{"SELECT COUNT(*) FROM reconcilers WHERE owner = 'acme'", 2},
+		{"SELECT COUNT(*) FROM reconcilers WHERE classification = 'sotw'", 1},
+		{"SELECT COUNT(*) FROM signals WHERE reconciler_id = 'acme/widgets#controllers/widget_controller.go#40'", 3},
+		{"SELECT COUNT(*) FROM signals s LEFT JOIN reconcilers r ON s.reconciler_id = r.id WHERE r.id IS NULL", 0},
+	}
+	for _, tt := range tests {
+		var got int
+		if err := db.QueryRow(tt.query).Scan(&got); err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+
+	// Exporting the same IDs again violates the reconcilers primary key
+	// and must leave the database unchanged.
+	if err := ExportSQLite(db, reconcilers); err == nil {
+		t.Error("ExportSQLite of duplicate IDs succeeded, want error")
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM signals").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 5 {
+		t.Errorf("signals after failed export = %d, want 5", count)
+	}
+}
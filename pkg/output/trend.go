@@ -0,0 +1,52 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rg0now/k8s-controller-survey/pkg/models"
+)
+
+// TrendPoint is the classification breakdown of one result snapshot.
+type TrendPoint struct {
+	Label            string             `json:"label"`
+	TotalReconcilers int                `json:"total_reconcilers"`
+	AverageScore     float64            `json:"average_score"`
+	Percentages      map[string]float64 `json:"percentages"`
+}
+
+// NewTrendPoint computes the percentage of reconcilers per classification
+// from a snapshot's summary.
+func NewTrendPoint(label string, summary Summary) TrendPoint {
+	point := TrendPoint{
+		Label:            label,
+		TotalReconcilers: summary.TotalReconcilers,
+		AverageScore:     summary.AverageScore,
+		Percentages:      make(map[string]float64),
+	}
+	for _, class := range models.Classifications {
+		if summary.TotalReconcilers > 0 {
+			point.Percentages[class] = 100 * float64(summary.ByClassification[class]) / float64(summary.TotalReconcilers)
+		} else {
+			point.Percentages[class] = 0
+		}
+	}
+	return point
+}
+
+// PrintTrend prints one row of classification percentages per snapshot.
+func PrintTrend(w io.Writer, points []TrendPoint) {
+	fmt.Fprintf(w, "%-24s %8s %8s", "INPUT", "TOTAL", "AVG")
+	for _, class := range models.Classifications {
+		fmt.Fprintf(w, " %15s", class)
+	}
+	fmt.Fprintf(w, "\n")
+
+	for _, p := range points {
+		fmt.Fprintf(w, "%-24s %8d %8.2f", p.Label, p.TotalReconcilers, p.AverageScore)
+		for _, class := range models.Classifications {
+			fmt.Fprintf(w, " %14.1f%%", p.Percentages[class])
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
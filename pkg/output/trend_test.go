@@ -0,0 +1,58 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewTrendPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary Summary
+		want    map[string]float64
+	}{
+		{
+			name:    "percentages of the total",
+			summary: Summary{TotalReconcilers: 4, ByClassification: map[string]int{"edge_triggered": 1, "sotw": 3}},
+			want:    map[string]float64{"edge_triggered": 25, "mostly_edge": 0, "mostly_sotw": 0, "sotw": 75},
+		},
+		{
+			name:    "empty snapshot",
+			summary: Summary{},
+			want:    map[string]float64{"edge_triggered": 0, "mostly_edge": 0, "mostly_sotw": 0, "sotw": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTrendPoint("snap", tt.summary).Percentages; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTrendPoint() percentages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintTrend(t *testing.T) {
+	points := []TrendPoint{
+		NewTrendPoint("2023.jsonl", Summary{TotalReconcilers: 2, AverageScore: 1.5, ByClassification: map[string]int{"sotw": 2}}),
+		NewTrendPoint("2024.jsonl", Summary{TotalReconcilers: 4, AverageScore: -2, ByClassification: map[string]int{"edge_triggered": 4}}),
+	}
+	var buf bytes.Buffer
+	PrintTrend(&buf, points)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want a header and one row per snapshot:\n%s", len(lines), buf.String())
+	}
+	for i, want := range [][]string{
+		{"INPUT", "edge_triggered", "sotw"},
+		{"2023.jsonl", "1.50", "0.0%", "100.0%"},
+		{"2024.jsonl", "-2.00", "100.0%", "0.0%"},
+	} {
+		for _, field := range want {
+			if !strings.Contains(lines[i], field) {
+				t.Errorf("line %d %q lacks %q", i, lines[i], field)
+			}
+		}
+	}
+}